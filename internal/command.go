@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+)
+
+// CommonFlags are the flags every exporter command accepts. Commands embed it in their own flags with
+// `embed:""`, so these flags are parsed along with the command's.
+type CommonFlags struct {
+	AccountID           int    `short:"i" required:"true" help:"The New Relic Account ID"`
+	APIKey              string `short:"k" required:"true" help:"An API Key for the New Relic Acccount ID"`
+	Region              string `short:"r" default:"US" enum:"US,EU" help:"The region the New Relic Account is in. One of US or EU."`
+	ParallelWorkers     uint   `short:"w" required:"true" default:"10" hidden:"true" help:"Number of resources to export in parallel. Defaults to 10"`
+	ImportMode          string `default:"directives" enum:"directives,blocks,both" help:"How exported resources are imported. One of directives (terraform import commands), blocks (import blocks in imports.tf), or both."`
+	ProviderVersion     string `help:"A version constraint for the New Relic provider, such as \"~> 3.0\". By default, the provider version isn't constrained."`
+	SensitiveAPIKey     bool   `help:"Mark the api_key variable as sensitive."`
+	CreateAccountIdFile bool   `short:"a" hidden:"true" default:"true"`
+}
+
+// NewLogger returns the logger exporter commands report their progress with
+func NewLogger() hclog.Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Level:  hclog.Info,
+		Output: os.Stderr,
+	})
+}
+
+// NewClient returns a New Relic client for the account's region. The region comes before any other options,
+// so they can still override its URLs
+func (f *CommonFlags) NewClient(options ...newrelic.ConfigOption) (*newrelic.NewRelic, error) {
+	return newrelic.New(append([]newrelic.ConfigOption{
+		newrelic.ConfigPersonalAPIKey(f.APIKey),
+		newrelic.ConfigRegion(f.Region),
+	}, options...)...)
+}
+
+// WriteAccountFiles writes the provider files, unless the request asks to skip them, and .account_id if it was
+// asked for
+func (f *CommonFlags) WriteAccountFiles(request plugin.ExportCommandRequest) error {
+	if !request.SkipProviderOutput {
+		err := WriteProviderFiles(request.OutputDirectory, ProviderConfig{
+			AccountID:       f.AccountID,
+			Region:          f.Region,
+			Version:         f.ProviderVersion,
+			SensitiveAPIKey: f.SensitiveAPIKey,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.CreateAccountIdFile {
+		if err := os.WriteFile(filepath.Join(request.OutputDirectory, ".account_id"), []byte(fmt.Sprint(f.AccountID)), 0644); err != nil {
+			return fmt.Errorf("could not create .account_id: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ImportResponse writes import blocks for the directives if ImportMode asks for them, and returns the
+// response the CLI should process. exportErr is returned as is, so the CLI still processes the directives
+// of the exports that succeeded.
+func (f *CommonFlags) ImportResponse(outputDirectory string, directives []plugin.ImportDirective, exportErr error) (plugin.ExportResponse, error) {
+	if f.ImportMode != ImportModeDirectives {
+		if err := WriteImportBlocks(outputDirectory, directives); err != nil {
+			return plugin.ExportResponse{}, fmt.Errorf("error writing import blocks: %w", err)
+		}
+	}
+
+	if f.ImportMode == ImportModeBlocks {
+		directives = []plugin.ImportDirective{}
+	}
+
+	return plugin.ExportResponse{
+		Directives: directives,
+	}, exportErr
+}
+
+// WriteResourceFile writes file to <resource name>.tf in outputDirectory, and returns the directive that
+// imports the resource it declares
+func WriteResourceFile(outputDirectory string, file *hclwrite.File, id string, resourceType string, resourceName string) (plugin.ImportDirective, error) {
+	path, err := filepath.Abs(filepath.Join(outputDirectory, resourceName))
+	if err != nil {
+		return plugin.ImportDirective{}, err
+	}
+
+	filePtr, err := os.Create(fmt.Sprintf("%s.tf", path))
+	if err != nil {
+		return plugin.ImportDirective{}, err
+	}
+	defer filePtr.Close()
+
+	if _, err := file.WriteTo(filePtr); err != nil {
+		return plugin.ImportDirective{}, err
+	}
+
+	return plugin.ImportDirective{
+		Resource: resourceType,
+		Name:     resourceName,
+		ID:       id,
+	}, nil
+}
@@ -3,8 +3,10 @@ package internal
 import (
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func CreateHeredoc(text string, heredocMarker string, escapeSequences bool) hclwrite.Tokens {
@@ -48,3 +50,64 @@ func CreateHeredoc(text string, heredocMarker string, escapeSequences bool) hclw
 
 	return tokens
 }
+
+// CreateReference returns the tokens for an expression referring to another object in the
+// configuration, such as newrelic_synthetics_private_location.my_location.id or var.api_key
+func CreateReference(root string, attributes ...string) hclwrite.Tokens {
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attribute := range attributes {
+		traversal = append(traversal, hcl.TraverseAttr{Name: attribute})
+	}
+
+	return hclwrite.TokensForTraversal(traversal)
+}
+
+// CreateModulePath returns the tokens for a path to a file in the same directory as the configuration,
+// "${path.module}/<file name>"
+func CreateModulePath(fileName string) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+	}
+	tokens = append(tokens, CreateReference("path", "module")...)
+
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+
+	// the file name is quoted and escaped like any other string, and then its quotes are dropped
+	escaped := hclwrite.TokensForValue(cty.StringVal("/" + fileName))
+	tokens = append(tokens, escaped[1:len(escaped)-1]...)
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+}
+
+// SyntheticMonitorResourceTypes are the types of the resources synthetic monitors are exported as, whose IDs
+// are the monitors' entity GUIDs
+var SyntheticMonitorResourceTypes = []string{
+	"newrelic_synthetics_monitor",
+	"newrelic_synthetics_step_monitor",
+	"newrelic_synthetics_script_monitor",
+	"newrelic_synthetics_cert_check_monitor",
+	"newrelic_synthetics_broken_links_monitor",
+}
+
+// EntityResourceTypes are the types of the resources whose IDs are the GUIDs of the entities they manage.
+// Resources that only configure an entity, such as newrelic_entity_tags, are imported with the entity's GUID
+// too, but aren't the entity, so they're left out
+var EntityResourceTypes = append([]string{
+	"newrelic_application_settings",
+	"newrelic_browser_application",
+	"newrelic_one_dashboard",
+	"newrelic_one_dashboard_json",
+}, SyntheticMonitorResourceTypes...)
+
+// CreateEntityReference returns the tokens for an expression referring to the exported resource with the given
+// entity GUID, such as newrelic_synthetics_monitor.my_monitor.id, if names has recorded one of the
+// EntityResourceTypes with it. Otherwise, the GUID is used as is
+func CreateEntityReference(names *ResourceNames, guid string) hclwrite.Tokens {
+	resourceType, name, ok := names.LookupResource(guid, EntityResourceTypes...)
+	if !ok {
+		return hclwrite.TokensForValue(cty.StringVal(guid))
+	}
+
+	return CreateReference(resourceType, name, "id")
+}
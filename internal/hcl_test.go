@@ -9,6 +9,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
 )
 
 const heredocText = `This
@@ -50,4 +51,47 @@ var _ = Describe("Hcl", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(b.String()).To(ContainSubstring("\n  heredoctest = <<-EOF\n%s\n  EOF", indentedAndEscaped))
 	})
+
+	It("generates a reference to another resource", func() {
+		test := hclwrite.NewFile()
+		block := test.Body().AppendNewBlock("test", nil)
+		block.Body().SetAttributeRaw("reftest", internal.CreateReference("resource_type", "resource_name", "id"))
+		block.Body().SetAttributeRaw("vartest", internal.CreateReference("var", "name"))
+
+		b := &bytes.Buffer{}
+		_, err := test.WriteTo(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.String()).To(ContainSubstring("reftest = resource_type.resource_name.id\n"))
+		Expect(b.String()).To(ContainSubstring("vartest = var.name\n"))
+	})
+	It("generates a path to a file in the module", func() {
+		test := hclwrite.NewFile()
+		block := test.Body().AppendNewBlock("test", nil)
+		block.Body().SetAttributeRaw("pathtest", internal.CreateModulePath("my_file.json"))
+		block.Body().SetAttributeRaw("escapetest", internal.CreateModulePath("${a}.json"))
+
+		b := &bytes.Buffer{}
+		_, err := test.WriteTo(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.String()).To(ContainSubstring(`pathtest   = "${path.module}/my_file.json"` + "\n"))
+		Expect(b.String()).To(ContainSubstring(`escapetest = "${path.module}/$${a}.json"` + "\n"))
+	})
+	It("generates a reference to an exported entity", func() {
+		names := internal.NewResourceNames()
+		names.Record(plugin.ImportDirective{Resource: "newrelic_synthetics_monitor", Name: names.Reserve("newrelic_synthetics_monitor", "GUID1", "My Monitor", "monitor"), ID: "GUID1"})
+		names.Record(plugin.ImportDirective{Resource: "newrelic_entity_tags", Name: names.Reserve("newrelic_entity_tags", "GUID3", "My App Tags", "entity"), ID: "GUID3"})
+
+		test := hclwrite.NewFile()
+		block := test.Body().AppendNewBlock("test", nil)
+		block.Body().SetAttributeRaw("exported", internal.CreateEntityReference(names, "GUID1"))
+		block.Body().SetAttributeRaw("unexported", internal.CreateEntityReference(names, "GUID2"))
+		block.Body().SetAttributeRaw("tagsonly", internal.CreateEntityReference(names, "GUID3"))
+
+		b := &bytes.Buffer{}
+		_, err := test.WriteTo(b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.String()).To(ContainSubstring("exported   = newrelic_synthetics_monitor.my_monitor.id\n"))
+		Expect(b.String()).To(ContainSubstring(`unexported = "GUID2"` + "\n"))
+		Expect(b.String()).To(ContainSubstring(`tagsonly   = "GUID3"` + "\n"))
+	})
 })
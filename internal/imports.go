@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	ImportModeDirectives = "directives"
+	ImportModeBlocks     = "blocks"
+	ImportModeBoth       = "both"
+
+	importsFileName = "imports.tf"
+)
+
+// WriteImportBlocks adds a Terraform 1.5+ import block for each directive to imports.tf in outputDirectory,
+// so every exported resource can be imported with a single terraform plan. If imports.tf already exists (for
+// instance, because another command exported to the same directory), its blocks are kept and any directive
+// whose address it already imports is skipped.
+func WriteImportBlocks(outputDirectory string, directives []plugin.ImportDirective) error {
+	path := filepath.Join(outputDirectory, importsFileName)
+
+	file := hclwrite.NewEmptyFile()
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if len(existing) > 0 {
+		var diags hcl.Diagnostics
+		file, diags = hclwrite.ParseConfig(existing, path, hcl.InitialPos)
+		if diags.HasErrors() {
+			return fmt.Errorf("could not parse %s: %w", path, diags)
+		}
+	}
+
+	imported := map[string]bool{}
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "import" || block.Body().GetAttribute("to") == nil {
+			continue
+		}
+
+		to := block.Body().GetAttribute("to").Expr().BuildTokens(nil).Bytes()
+		imported[strings.TrimSpace(string(to))] = true
+	}
+
+	// directives are collected concurrently, so sort them to keep the file stable between exports
+	sorted := append([]plugin.ImportDirective{}, directives...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Resource != sorted[j].Resource {
+			return sorted[i].Resource < sorted[j].Resource
+		}
+
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, directive := range sorted {
+		to := fmt.Sprintf("%s.%s", directive.Resource, directive.Name)
+		if imported[to] {
+			continue
+		}
+		imported[to] = true
+
+		if len(file.Body().Blocks()) > 0 {
+			file.Body().AppendNewline()
+		}
+
+		block := file.Body().AppendNewBlock("import", nil)
+		block.Body().SetAttributeRaw("to", CreateReference(directive.Resource, directive.Name))
+		block.Body().SetAttributeValue("id", cty.StringVal(directive.ID))
+	}
+
+	return os.WriteFile(path, file.Bytes(), 0644)
+}
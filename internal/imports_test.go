@@ -0,0 +1,79 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+var _ = Describe("Imports", func() {
+	var outputDirectory string
+
+	BeforeEach(func() {
+		var err error
+		outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+	})
+
+	It("Writes sorted import blocks", func() {
+		err := internal.WriteImportBlocks(outputDirectory, []plugin.ImportDirective{
+			{Resource: "type_b", Name: "name_a", ID: "3"},
+			{Resource: "type_a", Name: "name_b", ID: "2"},
+			{Resource: "type_a", Name: "name_a", ID: "1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := os.ReadFile(filepath.Join(outputDirectory, "imports.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`import {
+  to = type_a.name_a
+  id = "1"
+}
+
+import {
+  to = type_a.name_b
+  id = "2"
+}
+
+import {
+  to = type_b.name_a
+  id = "3"
+}
+`))
+	})
+
+	It("Keeps existing import blocks", func() {
+		err := internal.WriteImportBlocks(outputDirectory, []plugin.ImportDirective{
+			{Resource: "type_a", Name: "name_a", ID: "1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = internal.WriteImportBlocks(outputDirectory, []plugin.ImportDirective{
+			{Resource: "type_a", Name: "name_a", ID: "1"},
+			{Resource: "type_b", Name: "name_b", ID: "2"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := os.ReadFile(filepath.Join(outputDirectory, "imports.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`import {
+  to = type_a.name_a
+  id = "1"
+}
+
+import {
+  to = type_b.name_b
+  id = "2"
+}
+`))
+	})
+})
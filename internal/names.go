@@ -0,0 +1,263 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+const resourceNamesFileName = ".resource_names.json"
+
+// ResourceNames hands out Terraform resource names that are unique across an entire export. Names are reserved
+// for a resource type and an ID, since New Relic IDs are only unique within a type: an alert policy and an alert
+// channel can both have ID 1001. Each exported file is named after its resource, so names are unique across
+// resource types as well as within them, and never collide with the other files written to the output
+// directory. ResourceNames is safe for concurrent use, but names are handed out first come, first served, so
+// reserve names in a stable order before exporting concurrently if the names must be the same from one export
+// to the next.
+type ResourceNames struct {
+	lock     sync.Mutex
+	taken    map[string]bool
+	names    map[string]map[string]string
+	exported map[string]map[string]bool
+}
+
+// savedResourceNames is what Save writes to the output directory. Names are saved by resource type and then ID,
+// and the IDs of the resources recorded as exported by resource type
+type savedResourceNames struct {
+	Names    map[string]map[string]string `json:"names"`
+	Exported map[string][]string          `json:"exported"`
+}
+
+// legacyResourceNames is what Save wrote before names were reserved by resource type. Only the names of
+// recorded resources have a known type
+type legacyResourceNames struct {
+	Names map[string]string `json:"names"`
+	Types map[string]string `json:"types"`
+}
+
+func NewResourceNames() *ResourceNames {
+	return &ResourceNames{
+		taken:    map[string]bool{},
+		names:    map[string]map[string]string{},
+		exported: map[string]map[string]bool{},
+	}
+}
+
+// LoadResourceNames returns the resource names already handed out to the resources exported to outputDirectory,
+// as saved by Save. This lets every command exporting to the same directory hand out names that are unique
+// across all of them, give a resource the same name each time it's exported, and refer to resources other
+// commands exported using Lookup. If nothing has been exported to outputDirectory yet, no names are taken.
+func LoadResourceNames(outputDirectory string) (*ResourceNames, error) {
+	r := NewResourceNames()
+
+	b, err := os.ReadFile(filepath.Join(outputDirectory, resourceNamesFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	saved := savedResourceNames{}
+	if err := json.Unmarshal(b, &saved); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("could not parse %s: %w", resourceNamesFileName, err)
+		}
+
+		// the directory was exported to before names were reserved by resource type
+		legacy := legacyResourceNames{}
+		if err := json.Unmarshal(b, &legacy); err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", resourceNamesFileName, err)
+		}
+
+		saved = legacy.upgrade()
+	}
+
+	for resourceType, names := range saved.Names {
+		for id, name := range names {
+			r.reserved(resourceType)[id] = name
+			r.taken[name] = true
+		}
+	}
+
+	for resourceType, ids := range saved.Exported {
+		for _, id := range ids {
+			r.recorded(resourceType)[id] = true
+		}
+	}
+
+	return r, nil
+}
+
+// upgrade converts legacy names to the names of the resources with a known type. The files of the others are
+// still in the output directory, so their names are kept taken under an empty resource type, where no resource
+// will ever look them up
+func (l legacyResourceNames) upgrade() savedResourceNames {
+	saved := savedResourceNames{Names: map[string]map[string]string{"": {}}, Exported: map[string][]string{}}
+	for id, name := range l.Names {
+		resourceType, ok := l.Types[id]
+		if !ok {
+			saved.Names[""][id] = name
+			continue
+		}
+
+		if saved.Names[resourceType] == nil {
+			saved.Names[resourceType] = map[string]string{}
+		}
+		saved.Names[resourceType][id] = name
+		saved.Exported[resourceType] = append(saved.Exported[resourceType], id)
+	}
+
+	return saved
+}
+
+// Save records every name handed out so far, and every resource recorded as exported, in outputDirectory, to be
+// loaded by LoadResourceNames
+func (r *ResourceNames) Save(outputDirectory string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// IDs are sorted so the file doesn't change between exports of the same resources
+	exported := map[string][]string{}
+	for resourceType, ids := range r.exported {
+		for id := range ids {
+			exported[resourceType] = append(exported[resourceType], id)
+		}
+		sort.Strings(exported[resourceType])
+	}
+
+	b, err := json.MarshalIndent(savedResourceNames{Names: r.names, Exported: exported}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(outputDirectory, resourceNamesFileName), append(b, '\n'), 0644)
+}
+
+// Lookup returns the resource name already reserved for the resource of the given type with the given ID, if
+// there is one
+func (r *ResourceNames) Lookup(resourceType string, id string) (string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	name, ok := r.names[resourceType][id]
+	return name, ok
+}
+
+// Record notes each exported resource, so LookupResource can find it. Resources are recorded by their types
+// and import IDs, which are expected to be what their names were reserved with.
+func (r *ResourceNames) Record(directives ...plugin.ImportDirective) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, directive := range directives {
+		r.recorded(directive.Resource)[directive.ID] = true
+	}
+}
+
+// LookupResource returns the resource type and name of the exported resource with the given ID, if one of the
+// given types has been recorded with it. The types are checked in order
+func (r *ResourceNames) LookupResource(id string, resourceTypes ...string) (string, string, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, resourceType := range resourceTypes {
+		if r.exported[resourceType][id] {
+			return resourceType, r.names[resourceType][id], true
+		}
+	}
+
+	return "", "", false
+}
+
+// Reserve returns the resource name for the resource of the given type with the given ID, derived from the
+// object's display name. Reserving the same type and ID again returns the same resource name. If the name is
+// already taken by another resource, or by one of the files written alongside the resources, a hash of the ID
+// is appended to it; if the name is empty, or starts with a digit (which Terraform does not allow), it is
+// prefixed with fallback.
+func (r *ResourceNames) Reserve(resourceType string, id string, name string, fallback string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if reserved, ok := r.names[resourceType][id]; ok {
+		return reserved
+	}
+
+	base := ToSnakeCase(name)
+	if base == "" {
+		base = fmt.Sprintf("%s_%s", fallback, shortHash(id))
+	} else if unicode.IsDigit([]rune(base)[0]) {
+		base = fmt.Sprintf("%s_%s", fallback, base)
+	}
+
+	candidate := base
+	if r.isTaken(candidate) {
+		candidate = fmt.Sprintf("%s_%s", base, shortHash(id))
+	}
+
+	// two IDs sharing a hash prefix is vanishingly unlikely, but the same ID is reserved for different resource
+	// types, and a name must never be handed out twice
+	for i := 2; r.isTaken(candidate); i++ {
+		candidate = fmt.Sprintf("%s_%s_%d", base, shortHash(id), i)
+	}
+
+	r.taken[candidate] = true
+	r.reserved(resourceType)[id] = candidate
+	return candidate
+}
+
+// isTaken reports whether name has been handed out, or is the name of one of the files written alongside the
+// resources, which the resource's file would overwrite
+func (r *ResourceNames) isTaken(name string) bool {
+	if r.taken[name] {
+		return true
+	}
+
+	if name == strings.TrimSuffix(versionsFileName, ".tf") || name == strings.TrimSuffix(importsFileName, ".tf") {
+		return true
+	}
+
+	var accountID int
+	if _, err := fmt.Sscanf(name+".tf", providerFileNameFormat, &accountID); err == nil && fmt.Sprintf(providerFileNameFormat, accountID) == name+".tf" {
+		return true
+	}
+
+	return false
+}
+
+// reserved returns the names reserved for resourceType, keyed by ID. The lock must be held
+func (r *ResourceNames) reserved(resourceType string) map[string]string {
+	if r.names[resourceType] == nil {
+		r.names[resourceType] = map[string]string{}
+	}
+
+	return r.names[resourceType]
+}
+
+// recorded returns the IDs of the resources of resourceType recorded as exported. The lock must be held
+func (r *ResourceNames) recorded(resourceType string) map[string]bool {
+	if r.exported[resourceType] == nil {
+		r.exported[resourceType] = map[string]bool{}
+	}
+
+	return r.exported[resourceType]
+}
+
+// shortHash returns the first 8 hex characters of the SHA-256 hash of s
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:8]
+}
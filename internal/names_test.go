@@ -0,0 +1,144 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+var _ = Describe("ResourceNames", func() {
+	var names *internal.ResourceNames
+
+	BeforeEach(func() {
+		names = internal.NewResourceNames()
+	})
+
+	It("Snake cases names", func() {
+		Expect(names.Reserve("type_a", "a", "Hello World", "test")).To(Equal("hello_world"))
+	})
+
+	It("Returns the same name when an ID is reserved again", func() {
+		Expect(names.Reserve("type_a", "a", "Hello World", "test")).To(Equal("hello_world"))
+		Expect(names.Reserve("type_a", "a", "Something Else", "test")).To(Equal("hello_world"))
+	})
+
+	It("Disambiguates colliding names with a hash of the ID", func() {
+		Expect(names.Reserve("type_a", "a", "Login Check", "test")).To(Equal("login_check"))
+		Expect(names.Reserve("type_a", "b", "login-check", "test")).To(Equal("login_check_3e23e816"))
+		Expect(names.Reserve("type_a", "c", "LOGIN CHECK!", "test")).To(Equal("login_check_2e7d2c03"))
+	})
+
+	It("Names objects whose names have no usable characters", func() {
+		Expect(names.Reserve("type_a", "b", "!!!", "test")).To(Equal("test_3e23e816"))
+	})
+
+	It("Prefixes names that start with a digit", func() {
+		Expect(names.Reserve("type_a", "a", "24/7 Uptime", "test")).To(Equal("test_24_7_uptime"))
+	})
+
+	It("Reserves the same ID separately for each resource type", func() {
+		Expect(names.Reserve("type_a", "1001", "Ops", "test")).To(Equal("ops"))
+		Expect(names.Reserve("type_b", "1001", "Ops", "test")).To(Equal("ops_fe675fe7"))
+		Expect(names.Reserve("type_b", "1001", "Renamed", "test")).To(Equal("ops_fe675fe7"))
+
+		name, ok := names.Lookup("type_a", "1001")
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("ops"))
+
+		name, ok = names.Lookup("type_b", "1001")
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("ops_fe675fe7"))
+	})
+
+	It("Never hands out the same name twice", func() {
+		Expect(names.Reserve("type_a", "1001", "Ops", "test")).To(Equal("ops"))
+		Expect(names.Reserve("type_b", "1001", "Ops", "test")).To(Equal("ops_fe675fe7"))
+		Expect(names.Reserve("type_c", "1001", "Ops", "test")).To(Equal("ops_fe675fe7_2"))
+	})
+
+	It("Avoids the names of the files written alongside the resources", func() {
+		Expect(names.Reserve("type_a", "a", "Versions", "test")).To(Equal("versions_ca978112"))
+		Expect(names.Reserve("type_a", "b", "Imports", "test")).To(Equal("imports_3e23e816"))
+		Expect(names.Reserve("type_a", "c", "New Relic Provider 56789", "test")).To(Equal("new_relic_provider_56789"))
+		Expect(names.Reserve("type_a", "d", "newrelic_provider_56789", "test")).To(Equal("newrelic_provider_56789_18ac3e73"))
+		Expect(names.Reserve("type_a", "e", "newrelic_provider_main", "test")).To(Equal("newrelic_provider_main"))
+	})
+
+	It("Looks up reserved names", func() {
+		names.Reserve("type_a", "a", "Hello World", "test")
+
+		name, ok := names.Lookup("type_a", "a")
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("hello_world"))
+
+		_, ok = names.Lookup("type_a", "b")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Keeps names reserved by earlier exports to the same directory", func() {
+		outputDirectory, err := os.MkdirTemp("", "nrtftmp")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(outputDirectory)
+
+		loaded, err := internal.LoadResourceNames(outputDirectory)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Reserve("type_a", "a", "Login Check", "test")).To(Equal("login_check"))
+		Expect(loaded.Save(outputDirectory)).To(Succeed())
+
+		loaded, err = internal.LoadResourceNames(outputDirectory)
+		Expect(err).NotTo(HaveOccurred())
+		name, ok := loaded.Lookup("type_a", "a")
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("login_check"))
+		Expect(loaded.Reserve("type_a", "a", "Renamed", "test")).To(Equal("login_check"))
+		Expect(loaded.Reserve("type_a", "b", "login-check", "test")).To(Equal("login_check_3e23e816"))
+	})
+
+	It("Looks up the resources recorded by earlier exports", func() {
+		outputDirectory, err := os.MkdirTemp("", "nrtftmp")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(outputDirectory)
+
+		names.Record(plugin.ImportDirective{Resource: "type_a", Name: names.Reserve("type_a", "a", "Hello World", "test"), ID: "a"})
+		names.Reserve("type_a", "b", "Not Exported", "test")
+		Expect(names.Save(outputDirectory)).To(Succeed())
+
+		loaded, err := internal.LoadResourceNames(outputDirectory)
+		Expect(err).NotTo(HaveOccurred())
+
+		resourceType, name, ok := loaded.LookupResource("a", "type_b", "type_a")
+		Expect(ok).To(BeTrue())
+		Expect(resourceType).To(Equal("type_a"))
+		Expect(name).To(Equal("hello_world"))
+
+		_, _, ok = loaded.LookupResource("a", "type_b")
+		Expect(ok).To(BeFalse())
+
+		_, _, ok = loaded.LookupResource("b", "type_a")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Loads names saved before they were reserved by resource type", func() {
+		outputDirectory, err := os.MkdirTemp("", "nrtftmp")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(outputDirectory)
+
+		legacy := `{"names": {"a": "hello_world", "b": "not_exported"}, "types": {"a": "type_a"}}`
+		Expect(os.WriteFile(filepath.Join(outputDirectory, ".resource_names.json"), []byte(legacy), 0644)).To(Succeed())
+
+		loaded, err := internal.LoadResourceNames(outputDirectory)
+		Expect(err).NotTo(HaveOccurred())
+
+		resourceType, name, ok := loaded.LookupResource("a", "type_a")
+		Expect(ok).To(BeTrue())
+		Expect(resourceType).To(Equal("type_a"))
+		Expect(name).To(Equal("hello_world"))
+		Expect(loaded.Reserve("type_a", "a", "Renamed", "test")).To(Equal("hello_world"))
+		Expect(loaded.Reserve("type_b", "c", "Not Exported", "test")).To(Equal("not_exported_2e7d2c03"))
+	})
+})
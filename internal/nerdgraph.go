@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"context"
+)
+
+// NerdGraphClient is the part of the New Relic NerdGraph client used to run queries
+type NerdGraphClient interface {
+	QueryWithResponseAndContext(ctx context.Context, query string, variables map[string]interface{}, respBody interface{}) error
+}
+
+// EntitySearchResults is a single page of entities returned by an entitySearch query
+type EntitySearchResults[T any] struct {
+	NextCursor string `json:"nextCursor"`
+	Entities   []T    `json:"entities"`
+}
+
+// EntitySearchResponse is the response to an entitySearch query whose entities are of type T
+type EntitySearchResponse[T any] struct {
+	Actor struct {
+		EntitySearch struct {
+			Results EntitySearchResults[T] `json:"results"`
+		} `json:"entitySearch"`
+	} `json:"actor"`
+}
+
+// QueryPages runs a paginated query, passing each page's response to collect, which returns the cursor of the next
+// page and whether to keep reading. The query is expected to accept a $cursor variable, which is set from the
+// previous page's cursor. Reading stops when there are no pages left, when collect returns false, or when maxPages
+// pages have been read (0 means no limit). The returned bool is true only if pages were left unread because of
+// maxPages.
+func QueryPages[R any](ctx context.Context, client NerdGraphClient, query string, variables map[string]any, maxPages uint, collect func(R) (string, bool)) (bool, error) {
+	vars := make(map[string]any, len(variables)+1)
+	for k, v := range variables {
+		vars[k] = v
+	}
+
+	// a nil cursor is sent as null, which asks NerdGraph for the first page
+	vars["cursor"] = nil
+	for page := uint(1); ; page++ {
+		var response R
+		if err := client.QueryWithResponseAndContext(ctx, query, vars, &response); err != nil {
+			return false, err
+		}
+
+		nextCursor, more := collect(response)
+		if !more || nextCursor == "" {
+			return false, nil
+		}
+
+		if maxPages > 0 && page >= maxPages {
+			return true, nil
+		}
+
+		vars["cursor"] = nextCursor
+	}
+}
+
+// SearchEntities runs an entitySearch query, passing each page of entities to collect. Paging works as it does
+// for QueryPages.
+func SearchEntities[T any](ctx context.Context, client NerdGraphClient, query string, variables map[string]any, maxPages uint, collect func([]T) bool) (bool, error) {
+	return QueryPages(ctx, client, query, variables, maxPages, func(response EntitySearchResponse[T]) (string, bool) {
+		results := response.Actor.EntitySearch.Results
+		return results.NextCursor, collect(results.Entities)
+	})
+}
@@ -0,0 +1,88 @@
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+// pagedClient serves pages of entities, where each page's cursor is its index in pages
+type pagedClient struct {
+	pages   [][]string
+	queries int
+}
+
+func (p *pagedClient) QueryWithResponseAndContext(_ context.Context, _ string, variables map[string]interface{}, respBody interface{}) error {
+	p.queries++
+
+	page := 0
+	if cursor, ok := variables["cursor"].(string); ok {
+		fmt.Sscan(cursor, &page)
+	}
+
+	results := internal.EntitySearchResults[string]{Entities: p.pages[page]}
+	if page+1 < len(p.pages) {
+		results.NextCursor = fmt.Sprint(page + 1)
+	}
+
+	var response internal.EntitySearchResponse[string]
+	response.Actor.EntitySearch.Results = results
+
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, respBody)
+}
+
+var _ = Describe("NerdGraph", func() {
+	Describe("SearchEntities", func() {
+		var client *pagedClient
+
+		BeforeEach(func() {
+			client = &pagedClient{pages: [][]string{{"a", "b"}, {"c"}, {"d", "e"}}}
+		})
+
+		It("Follows the cursor until there are no pages left", func() {
+			collected := []string{}
+			truncated, err := internal.SearchEntities(context.Background(), client, "", nil, 0, func(page []string) bool {
+				collected = append(collected, page...)
+				return true
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(truncated).To(BeFalse())
+			Expect(collected).To(Equal([]string{"a", "b", "c", "d", "e"}))
+			Expect(client.queries).To(Equal(3))
+		})
+
+		It("Stops after the maximum number of pages", func() {
+			collected := []string{}
+			truncated, err := internal.SearchEntities(context.Background(), client, "", nil, 2, func(page []string) bool {
+				collected = append(collected, page...)
+				return true
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(truncated).To(BeTrue())
+			Expect(collected).To(Equal([]string{"a", "b", "c"}))
+			Expect(client.queries).To(Equal(2))
+		})
+
+		It("Stops when collect returns false", func() {
+			truncated, err := internal.SearchEntities(context.Background(), client, "", nil, 0, func(page []string) bool {
+				return false
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(truncated).To(BeFalse())
+			Expect(client.queries).To(Equal(1))
+		})
+	})
+})
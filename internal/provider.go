@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	providerSource         = "newrelic/newrelic"
+	versionsFileName       = "versions.tf"
+	providerFileNameFormat = "newrelic_provider_%d.tf"
+)
+
+// ProviderConfig describes the New Relic provider configuration written by WriteProviderFiles
+type ProviderConfig struct {
+	// AccountID is the default value of the account_id variable
+	AccountID int
+
+	// Region is the region the provider is configured for, US or EU
+	Region string
+
+	// Version is the version constraint for the provider in required_providers, such as "~> 3.0". If it's
+	// empty, the provider isn't pinned to any version
+	Version string
+
+	// SensitiveAPIKey marks the api_key variable as sensitive, so Terraform never shows its value
+	SensitiveAPIKey bool
+}
+
+// WriteProviderFiles writes versions.tf, which declares the New Relic provider in required_providers, and
+// newrelic_provider_<account id>.tf, which configures the provider along with the variables it's configured
+// with, to outputDirectory. Both files are overwritten if they already exist.
+func WriteProviderFiles(outputDirectory string, config ProviderConfig) error {
+	if err := os.WriteFile(filepath.Join(outputDirectory, versionsFileName), renderVersions(config).Bytes(), 0644); err != nil {
+		return fmt.Errorf("error creating %s: %w", versionsFileName, err)
+	}
+
+	providerFileName := fmt.Sprintf(providerFileNameFormat, config.AccountID)
+	if err := os.WriteFile(filepath.Join(outputDirectory, providerFileName), renderProvider(config).Bytes(), 0644); err != nil {
+		return fmt.Errorf("error creating provider file: %w", err)
+	}
+
+	return nil
+}
+
+func renderVersions(config ProviderConfig) *hclwrite.File {
+	newrelic := map[string]cty.Value{
+		"source": cty.StringVal(providerSource),
+	}
+	if config.Version != "" {
+		newrelic["version"] = cty.StringVal(config.Version)
+	}
+
+	file := hclwrite.NewEmptyFile()
+	terraform := file.Body().AppendNewBlock("terraform", nil)
+	requiredProviders := terraform.Body().AppendNewBlock("required_providers", nil)
+	requiredProviders.Body().SetAttributeValue("newrelic", cty.ObjectVal(newrelic))
+
+	return file
+}
+
+func renderProvider(config ProviderConfig) *hclwrite.File {
+	file := hclwrite.NewEmptyFile()
+	file.Body().AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# Configure the New Relic provider\n")},
+	})
+
+	provider := file.Body().AppendNewBlock("provider", []string{"newrelic"})
+	provider.Body().SetAttributeRaw("account_id", CreateReference("var", "account_id"))
+	provider.Body().SetAttributeRaw("api_key", CreateReference("var", "api_key"))
+	provider.Body().SetAttributeValue("region", cty.StringVal(config.Region))
+	file.Body().AppendNewline()
+
+	accountID := file.Body().AppendNewBlock("variable", []string{"account_id"})
+	accountID.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("number"))
+	accountID.Body().SetAttributeValue("description", cty.StringVal("The New Relic Account ID"))
+	accountID.Body().SetAttributeValue("default", cty.NumberIntVal(int64(config.AccountID)))
+	file.Body().AppendNewline()
+
+	apiKey := file.Body().AppendNewBlock("variable", []string{"api_key"})
+	apiKey.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+	apiKey.Body().SetAttributeValue("description", cty.StringVal("The New Relic API Key"))
+	if config.SensitiveAPIKey {
+		apiKey.Body().SetAttributeValue("sensitive", cty.True)
+	}
+
+	return file
+}
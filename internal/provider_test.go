@@ -0,0 +1,87 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+var _ = Describe("Provider", func() {
+	var outputDirectory string
+
+	BeforeEach(func() {
+		var err error
+		outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+	})
+
+	It("Writes the provider and its version constraint", func() {
+		err := internal.WriteProviderFiles(outputDirectory, internal.ProviderConfig{
+			AccountID:       56789,
+			Region:          "EU",
+			Version:         "~> 3.0",
+			SensitiveAPIKey: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := os.ReadFile(filepath.Join(outputDirectory, "versions.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`terraform {
+  required_providers {
+    newrelic = {
+      source  = "newrelic/newrelic"
+      version = "~> 3.0"
+    }
+  }
+}
+`))
+
+		b, err = os.ReadFile(filepath.Join(outputDirectory, "newrelic_provider_56789.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`# Configure the New Relic provider
+provider "newrelic" {
+  account_id = var.account_id
+  api_key    = var.api_key
+  region     = "EU"
+}
+
+variable "account_id" {
+  type        = number
+  description = "The New Relic Account ID"
+  default     = 56789
+}
+
+variable "api_key" {
+  type        = string
+  description = "The New Relic API Key"
+  sensitive   = true
+}
+`))
+	})
+
+	It("Leaves the version and api_key sensitivity out when they aren't wanted", func() {
+		err := internal.WriteProviderFiles(outputDirectory, internal.ProviderConfig{
+			AccountID: 56789,
+			Region:    "US",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := os.ReadFile(filepath.Join(outputDirectory, "versions.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(ContainSubstring(`source = "newrelic/newrelic"`))
+		Expect(string(b)).NotTo(ContainSubstring("version"))
+
+		b, err = os.ReadFile(filepath.Join(outputDirectory, "newrelic_provider_56789.tf"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(ContainSubstring(`region     = "US"`))
+		Expect(string(b)).NotTo(ContainSubstring("sensitive"))
+	})
+})
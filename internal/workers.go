@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+// ErrSkip can be returned by an export function to signal that an item was deliberately not exported.
+// It is not reported as a failure.
+var ErrSkip = errors.New("don't export")
+
+// exportResult is what a worker reports back for each item it exported
+type exportResult struct {
+	directive plugin.ImportDirective
+	err       error
+}
+
+// ExportInParallel calls export on every item using a pool of workers goroutines, and returns the import
+// directives of every successful export. If any exports failed, the returned error wraps
+// plugin.ErrSomeExportsFailed along with every individual error, so the CLI still processes the
+// directives for the exports that succeeded.
+func ExportInParallel[T any](ctx context.Context, workers uint, items []T, export func(context.Context, T) (plugin.ImportDirective, error)) ([]plugin.ImportDirective, error) {
+	if workers == 0 {
+		workers = 1
+	}
+
+	// goroutines cannot return values, so each worker sends the result of every export on this chan.
+	// It's big enough to hold every result, so workers never block on it, and nothing reads it until
+	// all the workers have finished
+	results := make(chan exportResult, len(items))
+	work := make(chan T)
+
+	// WaitGroups are used to synchronize on many concurrent units of work. Each worker calls wg.Done()
+	// once the work chan is closed and drained, and wg.Wait() blocks until all of them have.
+	wg := new(sync.WaitGroup)
+	wg.Add(int(workers))
+	for i := uint(0); i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			// ranging over a chan returns a value when one is available on the chan, and ends when the
+			// chan is closed.
+			for item := range work {
+				directive, err := export(ctx, item)
+				results <- exportResult{directive: directive, err: err}
+			}
+		}()
+	}
+
+	for _, item := range items {
+		work <- item
+	}
+	close(work)
+
+	// once every worker is done, nothing else can send a result, so the results can be closed and read
+	// from this goroutine alone
+	wg.Wait()
+	close(results)
+
+	directives := []plugin.ImportDirective{}
+	errs := []error{}
+	for result := range results {
+		if result.err != nil {
+			if !errors.Is(result.err, ErrSkip) {
+				errs = append(errs, result.err)
+			}
+			continue
+		}
+
+		directives = append(directives, result.directive)
+	}
+
+	if len(errs) > 0 {
+		return directives, fmt.Errorf("%w: the following errors occurred exporting:\n%w", plugin.ErrSomeExportsFailed, errors.Join(errs...))
+	}
+
+	return directives, nil
+}
@@ -0,0 +1,58 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+var _ = Describe("Workers", func() {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	It("Exports every item", func() {
+		var calls atomic.Int32
+		directives, err := internal.ExportInParallel(context.Background(), 16, items, func(_ context.Context, i int) (plugin.ImportDirective, error) {
+			calls.Add(1)
+			return plugin.ImportDirective{Resource: "test", Name: fmt.Sprint(i), ID: fmt.Sprint(i)}, nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(BeEquivalentTo(len(items)))
+		Expect(directives).To(HaveLen(len(items)))
+	})
+
+	It("Collects every error, and the directives that succeeded", func() {
+		directives, err := internal.ExportInParallel(context.Background(), 16, items, func(_ context.Context, i int) (plugin.ImportDirective, error) {
+			if i%10 == 0 {
+				return plugin.ImportDirective{}, fmt.Errorf("item %d failed", i)
+			}
+			return plugin.ImportDirective{Resource: "test", Name: fmt.Sprint(i), ID: fmt.Sprint(i)}, nil
+		})
+
+		Expect(err).To(MatchError(plugin.ErrSomeExportsFailed))
+		Expect(err.Error()).To(ContainSubstring("item 0 failed"))
+		Expect(err.Error()).To(ContainSubstring("item 90 failed"))
+		Expect(directives).To(HaveLen(90))
+	})
+
+	It("Does not report skipped items as failures", func() {
+		directives, err := internal.ExportInParallel(context.Background(), 0, items, func(_ context.Context, i int) (plugin.ImportDirective, error) {
+			if i%2 == 0 {
+				return plugin.ImportDirective{}, fmt.Errorf("item %d: %w", i, internal.ErrSkip)
+			}
+			return plugin.ImportDirective{Resource: "test", Name: fmt.Sprint(i), ID: fmt.Sprint(i)}, nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(directives).To(HaveLen(50))
+	})
+})
@@ -4,7 +4,15 @@ import (
 	"log"
 	"os"
 
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/applications"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/dashboards"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/logs"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/servicelevels"
 	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/synthetics"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/tags"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/workflows"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/workloads"
 	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
 )
 
@@ -19,5 +27,18 @@ func main() {
 		plugin.FromString(Version),
 		plugin.RPCProtocol,
 		synthetics.NewSyntheticExporterCommand(),
+		alerts.NewAlertPolicyExporterCommand(),
+		alerts.NewNrqlConditionExporterCommand(),
+		alerts.NewSyntheticsConditionExporterCommand(),
+		alerts.NewInfraConditionExporterCommand(),
+		alerts.NewAlertChannelExporterCommand(),
+		dashboards.NewDashboardExporterCommand(),
+		workflows.NewWorkflowExporterCommand(),
+		servicelevels.NewServiceLevelExporterCommand(),
+		workloads.NewWorkloadExporterCommand(),
+		applications.NewApplicationSettingsExporterCommand(),
+		applications.NewBrowserApplicationExporterCommand(),
+		tags.NewEntityTagExporterCommand(),
+		logs.NewLogRuleExporterCommand(),
 	)
 }
@@ -0,0 +1,408 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	nralerts "github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type AlertChannelExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	NameLike        string `short:"n" help:"Only export alert channels whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewAlertChannelExporterCommand(options ...newrelic.ConfigOption) *AlertChannelExporterCommand {
+	return &AlertChannelExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (c *AlertChannelExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(c)
+}
+
+func (c *AlertChannelExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-alert-channels",
+		Description: "Export legacy New Relic Alert Channels, the Alert Policies they notify for and the links between them, from the specified New Relic Account. A report of the channels that notify for policies no workflow covers is written to " + channelReportFileName,
+		Summary:     "Export legacy New Relic Alert Channels from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (c *AlertChannelExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(c)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	c.nrClient, err = c.NewClient(c.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	c.outputDirectory = request.OutputDirectory
+
+	c.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	channels, err := c.listChannels(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := c.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// the report covers every channel, including the ones terraform can't manage
+	if err := c.writeReport(ctx, channels); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	exportable := []nralerts.Channel{}
+	for _, channel := range channels {
+		if internal.IndexOf(string(channel.Type), channelTypes) < 0 {
+			c.logger.Info("WARN:", hclog.Fmt("alert channel %q is a %s channel, which terraform can't manage. Skipping", channel.Name, channel.Type))
+			continue
+		}
+
+		exportable = append(exportable, channel)
+	}
+
+	policyChannels := linkPolicyChannels(exportable)
+	policyIDs := []string{}
+	for _, links := range policyChannels {
+		policyIDs = append(policyIDs, links.PolicyID)
+	}
+
+	policyDirectives, err := exportPolicies(ctx, &c.nrClient.NerdGraph, c.AccountID, c.resourceNames, c.outputDirectory, c.logger, policyIDs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, channel := range exportable {
+		c.resourceNames.Reserve(tfAlertChannelType, strconv.Itoa(channel.ID), channel.Name, "channel")
+	}
+
+	// each policy's links are named after the policy
+	for _, links := range policyChannels {
+		policyName, ok := c.resourceNames.Lookup(tfAlertPolicyType, links.PolicyID)
+		if !ok {
+			policyName = fmt.Sprintf("policy_%s", links.PolicyID)
+		}
+
+		c.resourceNames.Reserve(tfAlertPolicyChannelType, policyChannelsImportID(links), policyName+"_channels", "policy_channels")
+	}
+
+	directives, channelsError := internal.ExportInParallel(ctx, c.ParallelWorkers, exportable, c.exportChannel)
+	linkDirectives, linksError := internal.ExportInParallel(ctx, c.ParallelWorkers, policyChannels, c.exportPolicyChannels)
+	directives = append(append(policyDirectives, directives...), linkDirectives...)
+	commandError := errors.Join(channelsError, linksError)
+
+	c.resourceNames.Record(directives...)
+	if err := c.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return c.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// listChannels lists the alert channels in the account matching the name filter. Alert channels are only
+// available from the REST API, which returns all of them at once
+func (c *AlertChannelExporterCommand) listChannels(ctx context.Context) ([]nralerts.Channel, error) {
+	allChannels, err := c.nrClient.Alerts.ListChannelsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing alert channels: %w", err)
+	}
+
+	channels := []nralerts.Channel{}
+	for _, channel := range allChannels {
+		if strings.Contains(strings.ToLower(channel.Name), strings.ToLower(c.NameLike)) {
+			channels = append(channels, *channel)
+		}
+	}
+
+	return channels, nil
+}
+
+// exportChannel writes a newrelic_alert_channel resource. New Relic never returns the keys and passwords
+// channels are configured with, so each of them is read from a sensitive variable that has to be set before the
+// configuration is applied
+func (c *AlertChannelExporterCommand) exportChannel(_ context.Context, channel nralerts.Channel) (plugin.ImportDirective, error) {
+	c.logger.Info("Exporting Alert Channel", "name", channel.Name)
+
+	importID := strconv.Itoa(channel.ID)
+	tfResourceType := tfAlertChannelType
+	tfResourceName := c.resourceNames.Reserve(tfResourceType, importID, channel.Name, "channel")
+
+	file := hclwrite.NewEmptyFile()
+	config := channel.Configuration
+	settings := []struct{ name, value string }{
+		{"recipients", config.Recipients},
+		{"include_json_attachment", config.IncludeJSONAttachment},
+		{"teams", config.Teams},
+		{"tags", config.Tags},
+		{"region", config.Region},
+		{"url", config.URL},
+		{"channel", config.Channel},
+		{"route_key", config.RouteKey},
+		{"user_id", config.UserID},
+		{"base_url", config.BaseURL},
+		{"auth_username", config.AuthUsername},
+		{"payload_type", config.PayloadType},
+	}
+
+	// the variables have to be declared before the resource that uses them is appended to the file
+	secrets := map[string]hclwrite.Tokens{}
+	for _, setting := range channelSecrets[string(channel.Type)] {
+		if setting == "auth_password" && config.AuthUsername == "" {
+			continue
+		}
+
+		variableName := fmt.Sprintf("alert_channel_%s_%s", tfResourceName, setting)
+		variable := file.Body().AppendNewBlock("variable", []string{variableName})
+		variable.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+		variable.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The %s of the %s alert channel", setting, channel.Name)))
+		variable.Body().SetAttributeValue("sensitive", cty.BoolVal(true))
+		file.Body().AppendNewline()
+
+		secrets[setting] = internal.CreateReference("var", variableName)
+	}
+
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeValue("name", cty.StringVal(channel.Name))
+	block.Body().SetAttributeValue("type", cty.StringVal(string(channel.Type)))
+
+	block.Body().AppendNewline()
+	configBlock := block.Body().AppendNewBlock("config", nil)
+	for _, setting := range settings {
+		if _, ok := secrets[setting.name]; !ok && setting.value != "" {
+			configBlock.Body().SetAttributeValue(setting.name, cty.StringVal(setting.value))
+		}
+	}
+
+	for _, setting := range channelSecrets[string(channel.Type)] {
+		if secret, ok := secrets[setting]; ok {
+			configBlock.Body().SetAttributeRaw(setting, secret)
+		}
+	}
+
+	// headers and payloads can be nested, so they're written as the JSON strings the provider also accepts
+	for _, setting := range []struct {
+		name  string
+		value map[string]any
+	}{
+		{"headers_string", config.Headers},
+		{"payload_string", config.Payload},
+	} {
+		if len(setting.value) == 0 {
+			continue
+		}
+
+		b, err := json.Marshal(setting.value)
+		if err != nil {
+			return plugin.ImportDirective{}, fmt.Errorf("error rendering alert channel %q: %w", channel.Name, err)
+		}
+		configBlock.Body().SetAttributeValue(setting.name, cty.StringVal(string(b)))
+	}
+
+	directive, err := internal.WriteResourceFile(c.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering alert channel %q: %w", channel.Name, err)
+	}
+
+	return directive, nil
+}
+
+func (c *AlertChannelExporterCommand) exportPolicyChannels(_ context.Context, links PolicyChannels) (plugin.ImportDirective, error) {
+	importID := policyChannelsImportID(links)
+	tfResourceType := tfAlertPolicyChannelType
+	tfResourceName := c.resourceNames.Reserve(tfResourceType, importID, "", "policy_channels")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeRaw("policy_id", policyReference(c.resourceNames, c.logger, links.PolicyID))
+
+	channelIDs := []hclwrite.Tokens{}
+	for _, id := range links.ChannelIDs {
+		name, _ := c.resourceNames.Lookup(tfAlertChannelType, strconv.Itoa(id))
+		channelIDs = append(channelIDs, internal.CreateReference(tfAlertChannelType, name, "id"))
+	}
+	block.Body().SetAttributeRaw("channel_ids", hclwrite.TokensForTuple(channelIDs))
+
+	directive, err := internal.WriteResourceFile(c.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering the alert channels of policy %s: %w", links.PolicyID, err)
+	}
+
+	return directive, nil
+}
+
+// writeReport writes a report of the channels that notify for policies no enabled workflow covers, along with
+// the channels that aren't linked to any policy, to plan the move from alert channels to workflows
+func (c *AlertChannelExporterCommand) writeReport(ctx context.Context, channels []nralerts.Channel) error {
+	coveredPolicies, coversAll, err := c.searchWorkflowPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	policyIDs := []string{}
+	for _, channel := range channels {
+		for _, id := range channel.Links.PolicyIDs {
+			policyIDs = append(policyIDs, strconv.Itoa(id))
+		}
+	}
+
+	policyNames := map[string]string{}
+	if len(policyIDs) > 0 {
+		policies, err := searchPolicies(ctx, &c.nrClient.NerdGraph, c.AccountID, map[string]any{"ids": policyIDs})
+		if err != nil {
+			return err
+		}
+
+		for _, policy := range policies {
+			policyNames[policy.ID] = policy.Name
+		}
+	}
+
+	rows := []string{}
+	for _, channel := range channels {
+		uncovered := []string{}
+		for _, id := range channel.Links.PolicyIDs {
+			policyID := strconv.Itoa(id)
+			if !coversAll && internal.IndexOf(policyID, coveredPolicies) < 0 {
+				uncovered = append(uncovered, fmt.Sprintf("%s (%s)", policyNames[policyID], policyID))
+			}
+		}
+
+		switch {
+		case len(channel.Links.PolicyIDs) == 0:
+			rows = append(rows, fmt.Sprintf("| %s | %s | not linked to any policy |", channel.Name, channel.Type))
+		case len(uncovered) > 0:
+			rows = append(rows, fmt.Sprintf("| %s | %s | %s |", channel.Name, channel.Type, strings.Join(uncovered, ", ")))
+		}
+	}
+
+	report := &strings.Builder{}
+	report.WriteString("# Alert Channel Report\n\n")
+	if len(rows) == 0 {
+		report.WriteString("Every alert channel notifies for policies that an enabled workflow also notifies for.\n")
+	} else {
+		report.WriteString("These alert channels notify for policies that no enabled workflow notifies for, or aren't linked to any\n")
+		report.WriteString("policy. Each of them needs a workflow before it can be removed.\n\n")
+		report.WriteString("| Channel | Type | Policies without a workflow |\n")
+		report.WriteString("| --- | --- | --- |\n")
+		report.WriteString(strings.Join(rows, "\n") + "\n")
+	}
+
+	if err := os.WriteFile(filepath.Join(c.outputDirectory, channelReportFileName), []byte(report.String()), 0644); err != nil {
+		return fmt.Errorf("could not write %s: %w", channelReportFileName, err)
+	}
+
+	return nil
+}
+
+// searchWorkflowPolicies returns the IDs of the policies enabled workflows notify for. If an enabled workflow
+// doesn't filter on policies, it notifies for all of them, and the returned bool is true
+func (c *AlertChannelExporterCommand) searchWorkflowPolicies(ctx context.Context) ([]string, bool, error) {
+	policyIDs := []string{}
+	coversAll := false
+	queryVariables := map[string]any{"accountID": c.AccountID}
+	_, err := internal.QueryPages(ctx, &c.nrClient.NerdGraph, getWorkflowFilters, queryVariables, 0, func(response WorkflowFiltersResponse) (string, bool) {
+		page := response.Actor.Account.AiWorkflows.Workflows
+		for _, workflow := range page.Entities {
+			if !workflow.WorkflowEnabled {
+				continue
+			}
+
+			filtersPolicies := false
+			if workflow.IssuesFilter != nil {
+				for _, predicate := range workflow.IssuesFilter.Predicates {
+					if predicate.Attribute != workflowPolicyAttribute {
+						continue
+					}
+
+					filtersPolicies = true
+					if internal.IndexOf(predicate.Operator, workflowPolicyOperators) >= 0 {
+						policyIDs = append(policyIDs, predicate.Values...)
+					}
+				}
+			}
+
+			coversAll = coversAll || !filtersPolicies
+		}
+
+		return page.NextCursor, true
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error querying NerdGraph for workflows: %w", err)
+	}
+
+	return policyIDs, coversAll, nil
+}
+
+// linkPolicyChannels groups the channels by the policies they're linked to, in policy ID order
+func linkPolicyChannels(channels []nralerts.Channel) []PolicyChannels {
+	channelIDs := map[int][]int{}
+	for _, channel := range channels {
+		for _, policyID := range channel.Links.PolicyIDs {
+			channelIDs[policyID] = append(channelIDs[policyID], channel.ID)
+		}
+	}
+
+	policyIDs := []int{}
+	for policyID := range channelIDs {
+		policyIDs = append(policyIDs, policyID)
+	}
+	sort.Ints(policyIDs)
+
+	links := []PolicyChannels{}
+	for _, policyID := range policyIDs {
+		links = append(links, PolicyChannels{PolicyID: strconv.Itoa(policyID), ChannelIDs: channelIDs[policyID]})
+	}
+
+	return links
+}
+
+// policyChannelsImportID returns the ID terraform imports a policy's channels with,
+// <policy id>:<channel id>:<channel id>...
+func policyChannelsImportID(links PolicyChannels) string {
+	ids := []string{links.PolicyID}
+	for _, id := range links.ChannelIDs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	return strings.Join(ids, ":")
+}
@@ -0,0 +1,192 @@
+package alerts_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Alert Channels", func() {
+	It("Generates help", func() {
+		c := &alerts.AlertChannelExporterCommand{}
+		helpText, err := c.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -n, --name-like=STRING           Only export alert channels whose names
+                                   contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		c := &alerts.AlertChannelExporterCommand{}
+		info, err := c.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-alert-channels"))
+		Expect(info.Version).To(Equal(plugin.FromString(alerts.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *alerts.AlertChannelExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = alerts.NewAlertChannelExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports the channels terraform can manage and their links to policies", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_api", ID: "1002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "staging_api", ID: "1003"},
+				plugin.ImportDirective{Resource: "newrelic_alert_channel", Name: "on_call_email", ID: "5001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_channel", Name: "checkout_slack", ID: "5002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_channel", Name: "deploy_webhook", ID: "1003"},
+				plugin.ImportDirective{Resource: "newrelic_alert_channel", Name: "unused_pagerduty", ID: "5005"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy_channel", Name: "production_checkout_channels", ID: "1001:5001:5002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy_channel", Name: "production_api_channels", ID: "1002:5001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy_channel", Name: "staging_api_channels", ID: "1003:1003"},
+			))
+
+			Expect(readFile("on_call_email.tf")).To(Equal(`resource "newrelic_alert_channel" "on_call_email" {
+  name = "On-Call Email"
+  type = "email"
+
+  config {
+    recipients              = "oncall@example.com"
+    include_json_attachment = "true"
+  }
+}
+`))
+			Expect(readFile("checkout_slack.tf")).To(Equal(`variable "alert_channel_checkout_slack_url" {
+  type        = string
+  description = "The url of the Checkout Slack alert channel"
+  sensitive   = true
+}
+
+resource "newrelic_alert_channel" "checkout_slack" {
+  name = "Checkout Slack"
+  type = "slack"
+
+  config {
+    channel = "#checkout-alerts"
+    url     = var.alert_channel_checkout_slack_url
+  }
+}
+`))
+			Expect(readFile("deploy_webhook.tf")).To(Equal(`variable "alert_channel_deploy_webhook_auth_password" {
+  type        = string
+  description = "The auth_password of the Deploy Webhook alert channel"
+  sensitive   = true
+}
+
+resource "newrelic_alert_channel" "deploy_webhook" {
+  name = "Deploy Webhook"
+  type = "webhook"
+
+  config {
+    base_url       = "https://hooks.example.com/alerts"
+    auth_username  = "newrelic"
+    payload_type   = "application/json"
+    auth_password  = var.alert_channel_deploy_webhook_auth_password
+    headers_string = "{\"X-Team\":\"api\"}"
+    payload_string = "{\"account\":\"$ACCOUNT_ID\",\"condition\":\"$CONDITION_NAME\"}"
+  }
+}
+`))
+			Expect(readFile("production_checkout_channels.tf")).To(Equal(`resource "newrelic_alert_policy_channel" "production_checkout_channels" {
+  policy_id   = newrelic_alert_policy.production_checkout.id
+  channel_ids = [newrelic_alert_channel.on_call_email.id, newrelic_alert_channel.checkout_slack.id]
+}
+`))
+			// alert policies and channels are numbered separately, so a channel and the policy it notifies for
+			// can share an ID
+			Expect(readFile("staging_api.tf")).To(ContainSubstring(`resource "newrelic_alert_policy" "staging_api" {`))
+			Expect(readFile("staging_api_channels.tf")).To(Equal(`resource "newrelic_alert_policy_channel" "staging_api_channels" {
+  policy_id   = newrelic_alert_policy.staging_api.id
+  channel_ids = [newrelic_alert_channel.deploy_webhook.id]
+}
+`))
+		})
+
+		It("Reports the channels that notify for policies no workflow covers", func() {
+			export()
+			Expect(readFile("alert_channel_report.md")).To(Equal(`# Alert Channel Report
+
+These alert channels notify for policies that no enabled workflow notifies for, or aren't linked to any
+policy. Each of them needs a workflow before it can be removed.
+
+| Channel | Type | Policies without a workflow |
+| --- | --- | --- |
+| On-Call Email | email | Production API (1002) |
+| Deploy Webhook | webhook | Staging API (1003) |
+| Jane Doe | user | Production API (1002) |
+| Unused PagerDuty | pagerduty | not linked to any policy |
+`))
+		})
+
+		It("Filters channels by name", func() {
+			resp := export("-n", "slack")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_channel", Name: "checkout_slack", ID: "5002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy_channel", Name: "production_checkout_channels", ID: "1001:5002"},
+			))
+		})
+	})
+})
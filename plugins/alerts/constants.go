@@ -0,0 +1,137 @@
+package alerts
+
+const (
+	tfAlertPolicyType            = "newrelic_alert_policy"
+	tfNrqlAlertConditionType     = "newrelic_nrql_alert_condition"
+	tfMultiLocationConditionType = "newrelic_synthetics_multilocation_alert_condition"
+	tfInfraAlertConditionType    = "newrelic_infra_alert_condition"
+	tfAlertChannelType           = "newrelic_alert_channel"
+	tfAlertPolicyChannelType     = "newrelic_alert_policy_channel"
+
+	// channelReportFileName is the report the alert channel exporter writes, listing the channels that have no
+	// workflow equivalent
+	channelReportFileName = "alert_channel_report.md"
+
+	// workflowPolicyAttribute is the issue attribute workflows filter on to notify for specific policies
+	workflowPolicyAttribute = "labels.policyIds"
+
+	nrqlConditionTypeStatic   = "STATIC"
+	nrqlConditionTypeBaseline = "BASELINE"
+
+	infraConditionTypeProcessRunning    = "infra_process_running"
+	infraConditionTypeMetric            = "infra_metric"
+	infraConditionTypeHostNotResponding = "infra_host_not_responding"
+
+	getPolicies = `query($accountID: Int!, $cursor: String, $criteria: AlertsPoliciesSearchCriteriaInput) {
+	actor {
+		account(id: $accountID) {
+			alerts {
+				policiesSearch(cursor: $cursor, searchCriteria: $criteria) {
+					nextCursor
+					policies {
+						id
+						name
+						incidentPreference
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+	getNrqlConditions = `query($accountID: Int!, $cursor: String, $criteria: AlertsNrqlConditionsSearchCriteriaInput) {
+	actor {
+		account(id: $accountID) {
+			alerts {
+				nrqlConditionsSearch(cursor: $cursor, searchCriteria: $criteria) {
+					nextCursor
+					nrqlConditions {
+						id
+						name
+						description
+						enabled
+						policyId
+						runbookUrl
+						type
+						violationTimeLimitSeconds
+						nrql {
+							query
+						}
+						signal {
+							aggregationWindow
+							aggregationMethod
+							aggregationDelay
+							aggregationTimer
+							evaluationDelay
+							fillOption
+							fillValue
+							slideBy
+						}
+						expiration {
+							closeViolationsOnExpiration
+							expirationDuration
+							openViolationOnExpiration
+						}
+						terms {
+							operator
+							priority
+							threshold
+							thresholdDuration
+							thresholdOccurrences
+						}
+						... on AlertsNrqlBaselineCondition {
+							baselineDirection
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+	getWorkflowFilters = `query($accountID: Int!, $cursor: String) {
+	actor {
+		account(id: $accountID) {
+			aiWorkflows {
+				workflows(cursor: $cursor) {
+					nextCursor
+					entities {
+						name
+						workflowEnabled
+						issuesFilter {
+							predicates {
+								attribute
+								operator
+								values
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
+
+// these should be treated as constants as well, but Go
+// does not allow map or slice types to be constant
+var (
+	// channelTypes are the alert channel types terraform can manage
+	channelTypes = []string{"email", "opsgenie", "pagerduty", "slack", "victorops", "webhook"}
+
+	// channelSecrets are the settings of each alert channel type that New Relic never returns, named as the
+	// provider names them. A webhook's auth_password is only needed when it has an auth_username
+	channelSecrets = map[string][]string{
+		"opsgenie":  {"api_key"},
+		"pagerduty": {"service_key"},
+		"slack":     {"url"},
+		"victorops": {"key"},
+		"webhook":   {"auth_password"},
+	}
+
+	// workflowPolicyOperators are the operators a workflow's policy filter notifies for the listed policies with
+	workflowPolicyOperators = []string{"EXACTLY_MATCHES", "CONTAINS", "EQUAL"}
+)
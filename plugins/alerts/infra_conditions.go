@@ -0,0 +1,219 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	nralerts "github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type InfraConditionExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	PolicyID        []string `short:"p" help:"Only export the infrastructure conditions in this alert policy. May be specified multiple times."`
+	NameLike        string   `short:"n" help:"Only export infrastructure conditions whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewInfraConditionExporterCommand(options ...newrelic.ConfigOption) *InfraConditionExporterCommand {
+	return &InfraConditionExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (i *InfraConditionExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(i)
+}
+
+func (i *InfraConditionExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-infra-alert-conditions",
+		Description: "Export New Relic Infrastructure Alert Conditions, and the Alert Policies they belong to, from the specified New Relic Account",
+		Summary:     "Export New Relic Infrastructure Alert Conditions from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (i *InfraConditionExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(i)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	i.nrClient, err = i.NewClient(i.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	i.outputDirectory = request.OutputDirectory
+
+	i.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	conditions, err := i.listConditions(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := i.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	policyIDs := []string{}
+	for _, condition := range conditions {
+		policyIDs = append(policyIDs, strconv.Itoa(condition.PolicyID))
+	}
+
+	policyDirectives, err := exportPolicies(ctx, &i.nrClient.NerdGraph, i.AccountID, i.resourceNames, i.outputDirectory, i.logger, policyIDs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, condition := range conditions {
+		i.resourceNames.Reserve(tfInfraAlertConditionType, infraConditionImportID(condition), condition.Name, "condition")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, i.ParallelWorkers, conditions, i.exportCondition)
+	directives = append(policyDirectives, directives...)
+
+	i.resourceNames.Record(directives...)
+	if err := i.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return i.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// listConditions lists the infrastructure conditions matching the name filter in each of the policies asked
+// for, or in every policy in the account if none were. Like multi-location conditions, infrastructure
+// conditions are only available from a REST API
+func (i *InfraConditionExporterCommand) listConditions(ctx context.Context) ([]nralerts.InfrastructureCondition, error) {
+	policyIDs, err := listPolicyIDs(ctx, &i.nrClient.NerdGraph, i.AccountID, i.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []nralerts.InfrastructureCondition{}
+	for _, policyID := range policyIDs {
+		id, err := strconv.Atoi(policyID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert policy ID %q: %w", policyID, err)
+		}
+
+		policyConditions, err := i.nrClient.Alerts.ListInfrastructureConditionsWithContext(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error listing infrastructure conditions for alert policy %s: %w", policyID, err)
+		}
+
+		for _, condition := range policyConditions {
+			if strings.Contains(strings.ToLower(condition.Name), strings.ToLower(i.NameLike)) {
+				conditions = append(conditions, condition)
+			}
+		}
+	}
+
+	return conditions, nil
+}
+
+func (i *InfraConditionExporterCommand) exportCondition(_ context.Context, condition nralerts.InfrastructureCondition) (plugin.ImportDirective, error) {
+	switch condition.Type {
+	case infraConditionTypeProcessRunning, infraConditionTypeMetric, infraConditionTypeHostNotResponding:
+	default:
+		i.logger.Info("WARN:", hclog.Fmt("infrastructure condition %q is a %s condition, which terraform can't manage. Skipping", condition.Name, condition.Type))
+		return plugin.ImportDirective{}, internal.ErrSkip
+	}
+
+	i.logger.Info("Exporting Infrastructure Condition", "name", condition.Name)
+
+	importID := infraConditionImportID(condition)
+	tfResourceType := tfInfraAlertConditionType
+	tfResourceName := i.resourceNames.Reserve(tfResourceType, importID, condition.Name, "condition")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+
+	body.SetAttributeRaw("policy_id", policyReference(i.resourceNames, i.logger, strconv.Itoa(condition.PolicyID)))
+	body.SetAttributeValue("name", cty.StringVal(condition.Name))
+	if condition.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(condition.Description))
+	}
+	body.SetAttributeValue("type", cty.StringVal(condition.Type))
+	body.SetAttributeValue("enabled", cty.BoolVal(condition.Enabled))
+	if condition.RunbookURL != "" {
+		body.SetAttributeValue("runbook_url", cty.StringVal(condition.RunbookURL))
+	}
+
+	// which of these are set depends on the condition's type
+	for _, attribute := range []struct{ name, value string }{
+		{"event", condition.Event},
+		{"integration_provider", condition.IntegrationProvider},
+		{"select", condition.Select},
+		{"comparison", condition.Comparison},
+		{"where", condition.Where},
+		{"process_where", condition.ProcessWhere},
+	} {
+		if attribute.value != "" {
+			body.SetAttributeValue(attribute.name, cty.StringVal(attribute.value))
+		}
+	}
+
+	if condition.ViolationCloseTimer != nil {
+		body.SetAttributeValue("violation_close_timer", cty.NumberIntVal(int64(*condition.ViolationCloseTimer)))
+	}
+
+	renderInfraThreshold(body, "critical", condition.Critical)
+	renderInfraThreshold(body, "warning", condition.Warning)
+
+	directive, err := internal.WriteResourceFile(i.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering infrastructure condition %q: %w", condition.Name, err)
+	}
+
+	return directive, nil
+}
+
+func renderInfraThreshold(body *hclwrite.Body, blockType string, threshold *nralerts.InfrastructureConditionThreshold) {
+	if threshold == nil {
+		return
+	}
+
+	body.AppendNewline()
+	thresholdBlock := body.AppendNewBlock(blockType, nil)
+	thresholdBlock.Body().SetAttributeValue("duration", cty.NumberIntVal(int64(threshold.Duration)))
+	if threshold.Value != nil {
+		thresholdBlock.Body().SetAttributeValue("value", cty.NumberFloatVal(*threshold.Value))
+	}
+	if threshold.Function != "" {
+		thresholdBlock.Body().SetAttributeValue("time_function", cty.StringVal(threshold.Function))
+	}
+}
+
+// infraConditionImportID returns the ID terraform imports an infrastructure condition with,
+// <policy id>:<condition id>
+func infraConditionImportID(condition nralerts.InfrastructureCondition) string {
+	return fmt.Sprintf("%d:%d", condition.PolicyID, condition.ID)
+}
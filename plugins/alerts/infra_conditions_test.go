@@ -0,0 +1,167 @@
+package alerts_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Infrastructure Conditions", func() {
+	It("Generates help", func() {
+		i := &alerts.InfraConditionExporterCommand{}
+		helpText, err := i.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -p, --policy-id=POLICY-ID,...    Only export the infrastructure conditions in
+                                   this alert policy. May be specified multiple
+                                   times.
+  -n, --name-like=STRING           Only export infrastructure conditions whose
+                                   names contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		i := &alerts.InfraConditionExporterCommand{}
+		info, err := i.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-infra-alert-conditions"))
+		Expect(info.Version).To(Equal(plugin.FromString(alerts.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *alerts.InfraConditionExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = alerts.NewInfraConditionExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigInfrastructureBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports the conditions in every policy, skipping the ones terraform can't manage", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "staging_api", ID: "1003"},
+				plugin.ImportDirective{Resource: "newrelic_infra_alert_condition", Name: "checkout_host_not_reporting", ID: "1001:4001"},
+				plugin.ImportDirective{Resource: "newrelic_infra_alert_condition", Name: "checkout_worker_not_running", ID: "1001:4002"},
+				plugin.ImportDirective{Resource: "newrelic_infra_alert_condition", Name: "staging_cpu", ID: "1003:4003"},
+			))
+
+			Expect(readFile("checkout_host_not_reporting.tf")).To(Equal(`resource "newrelic_infra_alert_condition" "checkout_host_not_reporting" {
+  policy_id   = newrelic_alert_policy.production_checkout.id
+  name        = "Checkout Host Not Reporting"
+  type        = "infra_host_not_responding"
+  enabled     = true
+  runbook_url = "https://runbooks.example.com/hosts"
+  where       = "(hostname LIKE 'checkout-%')"
+
+  critical {
+    duration = 5
+  }
+}
+`))
+			Expect(readFile("checkout_worker_not_running.tf")).To(Equal(`resource "newrelic_infra_alert_condition" "checkout_worker_not_running" {
+  policy_id             = newrelic_alert_policy.production_checkout.id
+  name                  = "Checkout Worker Not Running"
+  type                  = "infra_process_running"
+  enabled               = true
+  comparison            = "equal"
+  where                 = "(hostname LIKE 'checkout-%')"
+  process_where         = "(commandName = 'checkout-worker')"
+  violation_close_timer = 24
+
+  critical {
+    duration = 1
+    value    = 0
+  }
+}
+`))
+			Expect(readFile("staging_cpu.tf")).To(Equal(`resource "newrelic_infra_alert_condition" "staging_cpu" {
+  policy_id   = newrelic_alert_policy.staging_api.id
+  name        = "Staging CPU"
+  description = "CPU is running hot"
+  type        = "infra_metric"
+  enabled     = false
+  event       = "SystemSample"
+  select      = "cpuPercent"
+  comparison  = "above"
+
+  critical {
+    duration      = 10
+    value         = 90
+    time_function = "all"
+  }
+
+  warning {
+    duration      = 10
+    value         = 75
+    time_function = "all"
+  }
+}
+`))
+		})
+
+		It("Filters conditions by policy and name", func() {
+			resp := export("-p", "1001", "-n", "worker")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_infra_alert_condition", Name: "checkout_worker_not_running", ID: "1001:4002"},
+			))
+		})
+	})
+})
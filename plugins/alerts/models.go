@@ -0,0 +1,121 @@
+package alerts
+
+import (
+	nralerts "github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+type AlertPolicy struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	IncidentPreference string `json:"incidentPreference"`
+}
+
+type PoliciesSearchResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				PoliciesSearch struct {
+					NextCursor string        `json:"nextCursor"`
+					Policies   []AlertPolicy `json:"policies"`
+				} `json:"policiesSearch"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+type NrqlCondition struct {
+	ID                        string                  `json:"id"`
+	Name                      string                  `json:"name"`
+	Description               string                  `json:"description"`
+	Enabled                   bool                    `json:"enabled"`
+	PolicyID                  string                  `json:"policyId"`
+	RunbookURL                string                  `json:"runbookUrl"`
+	Type                      string                  `json:"type"`
+	ViolationTimeLimitSeconds *int64                  `json:"violationTimeLimitSeconds"`
+	Nrql                      NrqlConditionQuery      `json:"nrql"`
+	Signal                    NrqlConditionSignal     `json:"signal"`
+	Expiration                NrqlConditionExpiration `json:"expiration"`
+	Terms                     []NrqlConditionTerm     `json:"terms"`
+	BaselineDirection         *string                 `json:"baselineDirection"`
+}
+
+type NrqlConditionQuery struct {
+	Query string `json:"query"`
+}
+
+// NrqlConditionSignal holds a condition's signal settings. NerdGraph returns null for the settings that don't
+// apply to the condition's aggregation method, so they're all optional
+type NrqlConditionSignal struct {
+	AggregationWindow *int64   `json:"aggregationWindow"`
+	AggregationMethod *string  `json:"aggregationMethod"`
+	AggregationDelay  *int64   `json:"aggregationDelay"`
+	AggregationTimer  *int64   `json:"aggregationTimer"`
+	EvaluationDelay   *int64   `json:"evaluationDelay"`
+	FillOption        *string  `json:"fillOption"`
+	FillValue         *float64 `json:"fillValue"`
+	SlideBy           *int64   `json:"slideBy"`
+}
+
+type NrqlConditionExpiration struct {
+	CloseViolationsOnExpiration bool   `json:"closeViolationsOnExpiration"`
+	ExpirationDuration          *int64 `json:"expirationDuration"`
+	OpenViolationOnExpiration   bool   `json:"openViolationOnExpiration"`
+}
+
+type NrqlConditionTerm struct {
+	Operator             string   `json:"operator"`
+	Priority             string   `json:"priority"`
+	Threshold            *float64 `json:"threshold"`
+	ThresholdDuration    int64    `json:"thresholdDuration"`
+	ThresholdOccurrences string   `json:"thresholdOccurrences"`
+}
+
+type NrqlConditionsSearchResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				NrqlConditionsSearch struct {
+					NextCursor     string          `json:"nextCursor"`
+					NrqlConditions []NrqlCondition `json:"nrqlConditions"`
+				} `json:"nrqlConditionsSearch"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+// MultiLocationCondition is a multi-location synthetics condition, along with the ID of the policy it belongs to
+type MultiLocationCondition struct {
+	PolicyID string
+	nralerts.MultiLocationSyntheticsCondition
+}
+
+// PolicyChannels are the alert channels that notify for an alert policy
+type PolicyChannels struct {
+	PolicyID   string
+	ChannelIDs []int
+}
+
+type WorkflowFilter struct {
+	Name            string `json:"name"`
+	WorkflowEnabled bool   `json:"workflowEnabled"`
+	IssuesFilter    *struct {
+		Predicates []struct {
+			Attribute string   `json:"attribute"`
+			Operator  string   `json:"operator"`
+			Values    []string `json:"values"`
+		} `json:"predicates"`
+	} `json:"issuesFilter"`
+}
+
+type WorkflowFiltersResponse struct {
+	Actor struct {
+		Account struct {
+			AiWorkflows struct {
+				Workflows struct {
+					NextCursor string           `json:"nextCursor"`
+					Entities   []WorkflowFilter `json:"entities"`
+				} `json:"workflows"`
+			} `json:"aiWorkflows"`
+		} `json:"account"`
+	} `json:"actor"`
+}
@@ -0,0 +1,271 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type NrqlConditionExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	PolicyID        []string `short:"p" help:"Only export the NRQL conditions in this alert policy. May be specified multiple times."`
+	NameLike        string   `short:"n" help:"Only export NRQL conditions whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewNrqlConditionExporterCommand(options ...newrelic.ConfigOption) *NrqlConditionExporterCommand {
+	return &NrqlConditionExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (n *NrqlConditionExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(n)
+}
+
+func (n *NrqlConditionExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-nrql-alert-conditions",
+		Description: "Export New Relic NRQL Alert Conditions, and the Alert Policies they belong to, from the specified New Relic Account",
+		Summary:     "Export New Relic NRQL Alert Conditions from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (n *NrqlConditionExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(n)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	n.nrClient, err = n.NewClient(n.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	n.outputDirectory = request.OutputDirectory
+
+	n.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	conditions, err := n.searchConditions(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := n.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// conditions refer to their policies by address, so export any of their policies that haven't been
+	// exported to this directory already
+	policyIDs := []string{}
+	for _, condition := range conditions {
+		policyIDs = append(policyIDs, condition.PolicyID)
+	}
+
+	policyDirectives, err := exportPolicies(ctx, &n.nrClient.NerdGraph, n.AccountID, n.resourceNames, n.outputDirectory, n.logger, policyIDs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, condition := range conditions {
+		n.resourceNames.Reserve(tfNrqlAlertConditionType, nrqlConditionImportID(condition), condition.Name, "condition")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, n.ParallelWorkers, conditions, n.exportCondition)
+	directives = append(policyDirectives, directives...)
+
+	n.resourceNames.Record(directives...)
+	if err := n.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return n.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchConditions pages through the NRQL conditions matching the name filter, in each of the policies asked
+// for, or in the whole account if none were
+func (n *NrqlConditionExporterCommand) searchConditions(ctx context.Context) ([]NrqlCondition, error) {
+	criteria := []map[string]any{}
+	for _, policyID := range n.PolicyID {
+		criteria = append(criteria, map[string]any{"policyId": policyID})
+	}
+
+	if len(criteria) == 0 {
+		criteria = append(criteria, map[string]any{})
+	}
+
+	conditions := []NrqlCondition{}
+	for _, c := range criteria {
+		if n.NameLike != "" {
+			c["nameLike"] = n.NameLike
+		}
+
+		queryVariables := map[string]any{"accountID": n.AccountID, "criteria": c}
+		_, err := internal.QueryPages(ctx, &n.nrClient.NerdGraph, getNrqlConditions, queryVariables, 0, func(response NrqlConditionsSearchResponse) (string, bool) {
+			search := response.Actor.Account.Alerts.NrqlConditionsSearch
+			conditions = append(conditions, search.NrqlConditions...)
+			return search.NextCursor, true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error querying NerdGraph for NRQL conditions: %w", err)
+		}
+	}
+
+	return conditions, nil
+}
+
+func (n *NrqlConditionExporterCommand) exportCondition(_ context.Context, condition NrqlCondition) (plugin.ImportDirective, error) {
+	if condition.Type != nrqlConditionTypeStatic && condition.Type != nrqlConditionTypeBaseline {
+		n.logger.Info("WARN:", hclog.Fmt("NRQL condition %q is a %s condition, which terraform can't manage. Skipping", condition.Name, condition.Type))
+		return plugin.ImportDirective{}, internal.ErrSkip
+	}
+
+	n.logger.Info("Exporting NRQL Condition", "name", condition.Name)
+
+	importID := nrqlConditionImportID(condition)
+	tfResourceType := tfNrqlAlertConditionType
+	tfResourceName := n.resourceNames.Reserve(tfResourceType, importID, condition.Name, "condition")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+
+	body.SetAttributeRaw("policy_id", policyReference(n.resourceNames, n.logger, condition.PolicyID))
+	body.SetAttributeValue("type", cty.StringVal(strings.ToLower(condition.Type)))
+	body.SetAttributeValue("name", cty.StringVal(condition.Name))
+	if condition.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(condition.Description))
+	}
+	body.SetAttributeValue("enabled", cty.BoolVal(condition.Enabled))
+	if condition.RunbookURL != "" {
+		body.SetAttributeValue("runbook_url", cty.StringVal(condition.RunbookURL))
+	}
+	setOptionalInt(body, "violation_time_limit_seconds", condition.ViolationTimeLimitSeconds)
+	setOptionalEnum(body, "baseline_direction", condition.BaselineDirection)
+
+	signal := condition.Signal
+	setOptionalInt(body, "aggregation_window", signal.AggregationWindow)
+	setOptionalEnum(body, "aggregation_method", signal.AggregationMethod)
+	setOptionalInt(body, "aggregation_delay", signal.AggregationDelay)
+	setOptionalInt(body, "aggregation_timer", signal.AggregationTimer)
+	setOptionalInt(body, "evaluation_delay", signal.EvaluationDelay)
+	setOptionalEnum(body, "fill_option", signal.FillOption)
+	if signal.FillValue != nil {
+		body.SetAttributeValue("fill_value", cty.NumberFloatVal(*signal.FillValue))
+	}
+	setOptionalInt(body, "slide_by", signal.SlideBy)
+
+	setOptionalInt(body, "expiration_duration", condition.Expiration.ExpirationDuration)
+	body.SetAttributeValue("open_violation_on_expiration", cty.BoolVal(condition.Expiration.OpenViolationOnExpiration))
+	body.SetAttributeValue("close_violations_on_expiration", cty.BoolVal(condition.Expiration.CloseViolationsOnExpiration))
+
+	body.AppendNewline()
+	nrql := body.AppendNewBlock("nrql", nil)
+	nrql.Body().SetAttributeValue("query", cty.StringVal(condition.Nrql.Query))
+
+	for _, term := range condition.Terms {
+		// terms are rendered as critical or warning blocks, named after their priority
+		termBlock := body.AppendNewBlock(strings.ToLower(term.Priority), nil)
+		termBlock.Body().SetAttributeValue("operator", cty.StringVal(strings.ToLower(term.Operator)))
+		if term.Threshold != nil {
+			termBlock.Body().SetAttributeValue("threshold", cty.NumberFloatVal(*term.Threshold))
+		}
+		termBlock.Body().SetAttributeValue("threshold_duration", cty.NumberIntVal(term.ThresholdDuration))
+		termBlock.Body().SetAttributeValue("threshold_occurrences", cty.StringVal(strings.ToLower(term.ThresholdOccurrences)))
+	}
+
+	directive, err := internal.WriteResourceFile(n.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering NRQL condition %q: %w", condition.Name, err)
+	}
+
+	return directive, nil
+}
+
+// nrqlConditionImportID returns the ID terraform imports a NRQL condition with, <policy id>:<condition id>:<type>
+func nrqlConditionImportID(condition NrqlCondition) string {
+	return fmt.Sprintf("%s:%s:%s", condition.PolicyID, condition.ID, strings.ToLower(condition.Type))
+}
+
+// exportPolicies exports each of the alert policies with the given IDs that hasn't already been exported to
+// outputDirectory, so that conditions can refer to every one of them by address
+func exportPolicies(ctx context.Context, client internal.NerdGraphClient, accountID int, names *internal.ResourceNames, outputDirectory string, logger hclog.Logger, policyIDs []string) ([]plugin.ImportDirective, error) {
+	missing := []string{}
+	for _, id := range policyIDs {
+		if _, ok := names.Lookup(tfAlertPolicyType, id); !ok && internal.IndexOf(id, missing) < 0 {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return []plugin.ImportDirective{}, nil
+	}
+
+	policies, err := searchPolicies(ctx, client, accountID, map[string]any{"ids": missing})
+	if err != nil {
+		return nil, err
+	}
+
+	directives := []plugin.ImportDirective{}
+	for _, policy := range policies {
+		logger.Info("Exporting Alert Policy", "name", policy.Name)
+		directive, err := renderPolicy(outputDirectory, names, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives, nil
+}
+
+// policyReference returns the address of the exported policy with the given ID. If the policy hasn't been
+// exported, the ID is used as is
+func policyReference(names *internal.ResourceNames, logger hclog.Logger, policyID string) hclwrite.Tokens {
+	name, ok := names.Lookup(tfAlertPolicyType, policyID)
+	if !ok {
+		logger.Info("WARN:", hclog.Fmt("alert policy %s was not exported, so it is referred to by ID", policyID))
+		return hclwrite.TokensForValue(cty.StringVal(policyID))
+	}
+
+	return internal.CreateReference(tfAlertPolicyType, name, "id")
+}
+
+func setOptionalInt(body *hclwrite.Body, name string, value *int64) {
+	if value != nil {
+		body.SetAttributeValue(name, cty.NumberIntVal(*value))
+	}
+}
+
+// setOptionalEnum sets a NerdGraph enum value, which the provider expects in lower case
+func setOptionalEnum(body *hclwrite.Body, name string, value *string) {
+	if value != nil {
+		body.SetAttributeValue(name, cty.StringVal(strings.ToLower(*value)))
+	}
+}
@@ -0,0 +1,194 @@
+package alerts_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("NRQL Conditions", func() {
+	It("Generates help", func() {
+		n := &alerts.NrqlConditionExporterCommand{}
+		helpText, err := n.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -p, --policy-id=POLICY-ID,...    Only export the NRQL conditions in this alert
+                                   policy. May be specified multiple times.
+  -n, --name-like=STRING           Only export NRQL conditions whose names
+                                   contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		n := &alerts.NrqlConditionExporterCommand{}
+		info, err := n.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-nrql-alert-conditions"))
+		Expect(info.Version).To(Equal(plugin.FromString(alerts.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(command plugin.ExportCommand, args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		It("Exports conditions along with the policies they belong to", func() {
+			resp := export(alerts.NewNrqlConditionExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			))
+
+			// the OUTLIER condition can't be managed by terraform, so it isn't exported
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "staging_api", ID: "1003"},
+				plugin.ImportDirective{Resource: "newrelic_nrql_alert_condition", Name: "high_error_rate", ID: "1001:2001:static"},
+				plugin.ImportDirective{Resource: "newrelic_nrql_alert_condition", Name: "throughput_anomaly", ID: "1001:2002:baseline"},
+				plugin.ImportDirective{Resource: "newrelic_nrql_alert_condition", Name: "staging_latency", ID: "1003:2004:static"},
+			))
+
+			for _, directive := range resp.Directives {
+				Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
+			}
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "high_error_rate.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_nrql_alert_condition" "high_error_rate" {
+  policy_id                      = newrelic_alert_policy.production_checkout.id
+  type                           = "static"
+  name                           = "High Error Rate"
+  description                    = "Checkout errors are elevated"
+  enabled                        = true
+  runbook_url                    = "https://runbooks.example.com/checkout"
+  violation_time_limit_seconds   = 86400
+  aggregation_window             = 60
+  aggregation_method             = "event_flow"
+  aggregation_delay              = 120
+  fill_option                    = "static"
+  fill_value                     = 0
+  expiration_duration            = 600
+  open_violation_on_expiration   = false
+  close_violations_on_expiration = true
+
+  nrql {
+    query = "SELECT percentage(count(*), WHERE error IS true) FROM Transaction WHERE appName = 'checkout'"
+  }
+  critical {
+    operator              = "above"
+    threshold             = 5
+    threshold_duration    = 300
+    threshold_occurrences = "all"
+  }
+  warning {
+    operator              = "above_or_equals"
+    threshold             = 2.5
+    threshold_duration    = 300
+    threshold_occurrences = "at_least_once"
+  }
+}
+`))
+		})
+
+		It("Renders baseline conditions", func() {
+			export(alerts.NewNrqlConditionExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			), "-n", "Throughput")
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "throughput_anomaly.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_nrql_alert_condition" "throughput_anomaly" {
+  policy_id                      = newrelic_alert_policy.production_checkout.id
+  type                           = "baseline"
+  name                           = "Throughput Anomaly"
+  enabled                        = true
+  baseline_direction             = "upper_and_lower"
+  aggregation_window             = 300
+  aggregation_method             = "event_timer"
+  aggregation_timer              = 60
+  fill_option                    = "none"
+  slide_by                       = 30
+  open_violation_on_expiration   = false
+  close_violations_on_expiration = false
+
+  nrql {
+    query = "SELECT count(*) FROM Transaction"
+  }
+  critical {
+    operator              = "above"
+    threshold             = 3
+    threshold_duration    = 600
+    threshold_occurrences = "all"
+  }
+}
+`))
+		})
+
+		It("Refers to policies exported by the alert policy command", func() {
+			export(alerts.NewAlertPolicyExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			))
+
+			resp := export(alerts.NewNrqlConditionExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			), "-p", "1003")
+
+			// the policy was already exported, so only the condition is
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_nrql_alert_condition", Name: "staging_latency", ID: "1003:2004:static"},
+			))
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "staging_latency.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(MatchRegexp(`policy_id\s+= newrelic_alert_policy.staging_api.id`))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type AlertPolicyExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	PolicyID        []string `short:"p" help:"The ID of an alert policy to export. May be specified multiple times. If no policies are specified, every policy in the account is exported."`
+	NameLike        string   `short:"n" help:"Only export alert policies whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewAlertPolicyExporterCommand(options ...newrelic.ConfigOption) *AlertPolicyExporterCommand {
+	return &AlertPolicyExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (a *AlertPolicyExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(a)
+}
+
+func (a *AlertPolicyExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-alert-policies",
+		Description: "Export New Relic Alert Policies from the specified New Relic Account",
+		Summary:     "Export New Relic Alert Policies from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (a *AlertPolicyExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(a)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	a.nrClient, err = a.NewClient(a.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	a.outputDirectory = request.OutputDirectory
+
+	// names are shared with every other export to the same directory, so resources keep their names between
+	// exports and other commands can refer to them
+	a.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	policies, err := a.searchPolicies(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := a.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// reserve names in the order NerdGraph returned the policies, so colliding names are disambiguated the
+	// same way every time
+	for _, policy := range policies {
+		a.resourceNames.Reserve(tfAlertPolicyType, policy.ID, policy.Name, "policy")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, a.ParallelWorkers, policies, a.exportPolicy)
+
+	a.resourceNames.Record(directives...)
+	if err := a.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return a.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchPolicies pages through every alert policy in the account matching the policy IDs and name filter
+func (a *AlertPolicyExporterCommand) searchPolicies(ctx context.Context) ([]AlertPolicy, error) {
+	criteria := map[string]any{}
+	if len(a.PolicyID) > 0 {
+		criteria["ids"] = a.PolicyID
+	}
+
+	if a.NameLike != "" {
+		criteria["nameLike"] = a.NameLike
+	}
+
+	return searchPolicies(ctx, &a.nrClient.NerdGraph, a.AccountID, criteria)
+}
+
+func (a *AlertPolicyExporterCommand) exportPolicy(_ context.Context, policy AlertPolicy) (plugin.ImportDirective, error) {
+	a.logger.Info("Exporting Alert Policy", "name", policy.Name)
+	return renderPolicy(a.outputDirectory, a.resourceNames, policy)
+}
+
+// searchPolicies pages through every alert policy in the account matching criteria, an
+// AlertsPoliciesSearchCriteriaInput
+func searchPolicies(ctx context.Context, client internal.NerdGraphClient, accountID int, criteria map[string]any) ([]AlertPolicy, error) {
+	policies := []AlertPolicy{}
+	queryVariables := map[string]any{"accountID": accountID, "criteria": criteria}
+	_, err := internal.QueryPages(ctx, client, getPolicies, queryVariables, 0, func(response PoliciesSearchResponse) (string, bool) {
+		search := response.Actor.Account.Alerts.PoliciesSearch
+		policies = append(policies, search.Policies...)
+		return search.NextCursor, true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for alert policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// listPolicyIDs returns policyIDs, or the IDs of every alert policy in the account if there are none. It's
+// used by the commands that list conditions from the REST API, which only lists them one policy at a time
+func listPolicyIDs(ctx context.Context, client internal.NerdGraphClient, accountID int, policyIDs []string) ([]string, error) {
+	if len(policyIDs) > 0 {
+		return policyIDs, nil
+	}
+
+	policies, err := searchPolicies(ctx, client, accountID, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, policy := range policies {
+		ids = append(ids, policy.ID)
+	}
+
+	return ids, nil
+}
+
+// renderPolicy writes a newrelic_alert_policy resource for policy to outputDirectory. Policies are keyed by
+// their ID in names, so the other alerts commands can refer to them
+func renderPolicy(outputDirectory string, names *internal.ResourceNames, policy AlertPolicy) (plugin.ImportDirective, error) {
+	tfResourceType := tfAlertPolicyType
+	tfResourceName := names.Reserve(tfResourceType, policy.ID, policy.Name, "policy")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeValue("name", cty.StringVal(policy.Name))
+	block.Body().SetAttributeValue("incident_preference", cty.StringVal(policy.IncidentPreference))
+
+	directive, err := internal.WriteResourceFile(outputDirectory, file, policy.ID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering alert policy %q: %w", policy.Name, err)
+	}
+
+	return directive, nil
+}
@@ -0,0 +1,350 @@
+package alerts_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		a := &alerts.AlertPolicyExporterCommand{}
+		helpText, err := a.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -p, --policy-id=POLICY-ID,...    The ID of an alert policy to export. May be
+                                   specified multiple times. If no policies are
+                                   specified, every policy in the account is
+                                   exported.
+  -n, --name-like=STRING           Only export alert policies whose names
+                                   contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		a := &alerts.AlertPolicyExporterCommand{}
+		info, err := a.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-alert-policies"))
+		Expect(info.Version).To(Equal(plugin.FromString(alerts.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *alerts.AlertPolicyExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = alerts.NewAlertPolicyExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		It("Exports every policy in the account", func() {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: false,
+				PluginArgs: []string{
+					"-i", "56789",
+					"-k", "1234",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_api", ID: "1002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "staging_api", ID: "1003"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "synthetics", ID: "1004"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "synthetics_7f861bce", ID: "1005"},
+			))
+
+			for _, directive := range resp.Directives {
+				Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
+			}
+			Expect(filepath.Join(outputDirectory, "newrelic_provider_56789.tf")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDirectory, "versions.tf")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDirectory, ".account_id")).To(BeAnExistingFile())
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "staging_api.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_alert_policy" "staging_api" {
+  name                = "Staging API"
+  incident_preference = "PER_CONDITION_AND_TARGET"
+}
+`))
+		})
+
+		It("Exports specific policies", func() {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs: []string{
+					"-i", "56789",
+					"-k", "1234",
+					"-p", "1002",
+					"-p", "1004",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_api", ID: "1002"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "synthetics", ID: "1004"},
+			))
+		})
+
+		It("Exports policies matching a name", func() {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs: []string{
+					"-i", "56789",
+					"-k", "1234",
+					"-n", "Production",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directives).To(HaveLen(2))
+			Expect(filepath.Join(outputDirectory, "production_checkout.tf")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDirectory, "production_api.tf")).To(BeAnExistingFile())
+		})
+
+		It("Writes import blocks", func() {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs: []string{
+					"-i", "56789",
+					"-k", "1234",
+					"-p", "1001",
+					"--import-mode", "blocks",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directives).To(BeEmpty())
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "imports.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring("to = newrelic_alert_policy.production_checkout"))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		Cursor   string `json:"cursor"`
+		Criteria struct {
+			IDs      []string `json:"ids"`
+			PolicyID string   `json:"policyId"`
+			NameLike string   `json:"nameLike"`
+		} `json:"criteria"`
+	} `json:"variables"`
+}
+
+type policiesPage struct {
+	Data alerts.PoliciesSearchResponse `json:"data"`
+}
+
+type nrqlConditionsPage struct {
+	Data alerts.NrqlConditionsSearchResponse `json:"data"`
+}
+
+// pageFile returns the fixture for a page of search results. The first page has no cursor, later pages are
+// stored as <name>_<cursor>.json
+func pageFile(name string, cursor string) string {
+	if cursor == "" {
+		return fmt.Sprintf("testdata/%s.json", name)
+	}
+
+	return fmt.Sprintf("testdata/%s_%s.json", name, cursor)
+}
+
+// matchesName filters by name the way NerdGraph's nameLike criteria do
+func matchesName(name string, nameLike string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(nameLike))
+}
+
+func mockNerdGraphServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		// multi-location conditions come from the REST API, and are stored by policy ID
+		if strings.HasPrefix(r.URL.Path, "/alerts_location_failure_conditions/policies/") {
+			data, err := os.ReadFile(filepath.Join("testdata/location_failure_conditions", filepath.Base(r.URL.Path)))
+			if os.IsNotExist(err) {
+				data = []byte(`{"location_failure_conditions": []}`)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
+
+		// and so do infrastructure conditions, which are stored by the policy ID in the query
+		if r.URL.Path == "/alerts/conditions" {
+			data, err := os.ReadFile(filepath.Join("testdata/infra_conditions", r.URL.Query().Get("policy_id")+".json"))
+			if os.IsNotExist(err) {
+				data = []byte(`{"data": []}`)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
+
+		// as do alert channels, which are all returned at once
+		if r.URL.Path == "/alerts_channels.json" {
+			data, err := os.ReadFile("testdata/alert_channels.json")
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		criteria := request.Variables.Criteria
+		if strings.Contains(request.Query, "nrqlConditionsSearch") {
+			data, err := os.ReadFile(pageFile("get_nrql_conditions", request.Variables.Cursor))
+			if os.IsNotExist(err) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintln(w, err.Error())
+				return
+			}
+
+			var page nrqlConditionsPage
+			if err := json.Unmarshal(data, &page); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			search := &page.Data.Actor.Account.Alerts.NrqlConditionsSearch
+			conditions := []alerts.NrqlCondition{}
+			for _, condition := range search.NrqlConditions {
+				if criteria.PolicyID != "" && condition.PolicyID != criteria.PolicyID {
+					continue
+				}
+
+				if !matchesName(condition.Name, criteria.NameLike) {
+					continue
+				}
+
+				conditions = append(conditions, condition)
+			}
+			search.NrqlConditions = conditions
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+			return
+		}
+
+		if strings.Contains(request.Query, "aiWorkflows") {
+			data, err := os.ReadFile("testdata/get_workflows.json")
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
+
+		if strings.Contains(request.Query, "policiesSearch") {
+			data, err := os.ReadFile(pageFile("get_policies", request.Variables.Cursor))
+			if os.IsNotExist(err) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintln(w, err.Error())
+				return
+			}
+
+			// apply the search criteria the way NerdGraph would
+			var page policiesPage
+			if err := json.Unmarshal(data, &page); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			search := &page.Data.Actor.Account.Alerts.PoliciesSearch
+			policies := []alerts.AlertPolicy{}
+			for _, policy := range search.Policies {
+				if len(criteria.IDs) > 0 && !contains(criteria.IDs, policy.ID) {
+					continue
+				}
+
+				if !matchesName(policy.Name, criteria.NameLike) {
+					continue
+				}
+
+				policies = append(policies, policy)
+			}
+			search.Policies = policies
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(page)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte{})
+	}))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
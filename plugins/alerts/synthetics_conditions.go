@@ -0,0 +1,220 @@
+package alerts
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// monitorIDRegex matches the UUIDs the REST API identifies monitors with, as opposed to entity GUIDs
+var monitorIDRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+type SyntheticsConditionExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	PolicyID        []string `short:"p" help:"Only export the multi-location synthetics conditions in this alert policy. May be specified multiple times."`
+	NameLike        string   `short:"n" help:"Only export multi-location synthetics conditions whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewSyntheticsConditionExporterCommand(options ...newrelic.ConfigOption) *SyntheticsConditionExporterCommand {
+	return &SyntheticsConditionExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (m *SyntheticsConditionExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(m)
+}
+
+func (m *SyntheticsConditionExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-synthetics-alert-conditions",
+		Description: "Export New Relic Multi-Location Synthetics Alert Conditions, and the Alert Policies they belong to, from the specified New Relic Account. Monitors exported to the same directory are referred to by address",
+		Summary:     "Export New Relic Multi-Location Synthetics Alert Conditions from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (m *SyntheticsConditionExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(m)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	m.nrClient, err = m.NewClient(m.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	m.outputDirectory = request.OutputDirectory
+
+	m.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	conditions, err := m.listConditions(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := m.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	policyIDs := []string{}
+	for _, condition := range conditions {
+		policyIDs = append(policyIDs, condition.PolicyID)
+	}
+
+	policyDirectives, err := exportPolicies(ctx, &m.nrClient.NerdGraph, m.AccountID, m.resourceNames, m.outputDirectory, m.logger, policyIDs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, condition := range conditions {
+		m.resourceNames.Reserve(tfMultiLocationConditionType, multiLocationConditionImportID(condition), condition.Name, "condition")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, m.ParallelWorkers, conditions, m.exportCondition)
+	directives = append(policyDirectives, directives...)
+
+	m.resourceNames.Record(directives...)
+	if err := m.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return m.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// listConditions lists the multi-location conditions matching the name filter in each of the policies asked
+// for, or in every policy in the account if none were. Multi-location conditions are only available from the
+// REST API, which lists them one policy at a time
+func (m *SyntheticsConditionExporterCommand) listConditions(ctx context.Context) ([]MultiLocationCondition, error) {
+	policyIDs, err := listPolicyIDs(ctx, &m.nrClient.NerdGraph, m.AccountID, m.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []MultiLocationCondition{}
+	for _, policyID := range policyIDs {
+		id, err := strconv.Atoi(policyID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert policy ID %q: %w", policyID, err)
+		}
+
+		policyConditions, err := m.nrClient.Alerts.ListMultiLocationSyntheticsConditionsWithContext(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error listing multi-location synthetics conditions for alert policy %s: %w", policyID, err)
+		}
+
+		for _, condition := range policyConditions {
+			if !strings.Contains(strings.ToLower(condition.Name), strings.ToLower(m.NameLike)) {
+				continue
+			}
+
+			conditions = append(conditions, MultiLocationCondition{
+				PolicyID:                         policyID,
+				MultiLocationSyntheticsCondition: *condition,
+			})
+		}
+	}
+
+	return conditions, nil
+}
+
+func (m *SyntheticsConditionExporterCommand) exportCondition(_ context.Context, condition MultiLocationCondition) (plugin.ImportDirective, error) {
+	m.logger.Info("Exporting Multi-Location Synthetics Condition", "name", condition.Name)
+
+	importID := multiLocationConditionImportID(condition)
+	tfResourceType := tfMultiLocationConditionType
+	tfResourceName := m.resourceNames.Reserve(tfResourceType, importID, condition.Name, "condition")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+
+	body.SetAttributeRaw("policy_id", policyReference(m.resourceNames, m.logger, condition.PolicyID))
+	body.SetAttributeValue("name", cty.StringVal(condition.Name))
+	body.SetAttributeValue("enabled", cty.BoolVal(condition.Enabled))
+	if condition.RunbookURL != "" {
+		body.SetAttributeValue("runbook_url", cty.StringVal(condition.RunbookURL))
+	}
+	if condition.ViolationTimeLimitSeconds > 0 {
+		body.SetAttributeValue("violation_time_limit_seconds", cty.NumberIntVal(int64(condition.ViolationTimeLimitSeconds)))
+	}
+
+	entities := []hclwrite.Tokens{}
+	for _, entity := range condition.Entities {
+		entities = append(entities, m.monitorReference(entity))
+	}
+	body.SetAttributeRaw("entities", hclwrite.TokensForTuple(entities))
+
+	for _, term := range condition.Terms {
+		// terms are rendered as critical or warning blocks, named after their priority
+		body.AppendNewline()
+		termBlock := body.AppendNewBlock(strings.ToLower(term.Priority), nil)
+		termBlock.Body().SetAttributeValue("threshold", cty.NumberIntVal(int64(term.Threshold)))
+	}
+
+	directive, err := internal.WriteResourceFile(m.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering multi-location synthetics condition %q: %w", condition.Name, err)
+	}
+
+	return directive, nil
+}
+
+// monitorReference returns the address of the exported monitor a condition applies to. The REST API identifies
+// monitors by their monitor IDs, while the synthetics exporter records them by entity GUID, so monitor IDs are
+// turned into GUIDs first. If the monitor hasn't been exported, its GUID is used as is
+func (m *SyntheticsConditionExporterCommand) monitorReference(entity string) hclwrite.Tokens {
+	guid := entity
+	if monitorIDRegex.MatchString(entity) {
+		guid = monitorGUID(m.AccountID, entity)
+	}
+
+	resourceType, name, ok := m.resourceNames.LookupResource(guid, internal.SyntheticMonitorResourceTypes...)
+	if !ok {
+		m.logger.Info("WARN:", hclog.Fmt("synthetic monitor %s was not exported, so it is referred to by GUID", entity))
+		return hclwrite.TokensForValue(cty.StringVal(guid))
+	}
+
+	return internal.CreateReference(resourceType, name, "id")
+}
+
+// monitorGUID returns the entity GUID of the monitor with the given monitor ID, which is the unpadded base64
+// encoding of <account id>|SYNTH|MONITOR|<monitor id>
+func monitorGUID(accountID int, monitorID string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|SYNTH|MONITOR|%s", accountID, monitorID)))
+}
+
+// multiLocationConditionImportID returns the ID terraform imports a multi-location condition with,
+// <policy id>:<condition id>
+func multiLocationConditionImportID(condition MultiLocationCondition) string {
+	return fmt.Sprintf("%s:%d", condition.PolicyID, condition.ID)
+}
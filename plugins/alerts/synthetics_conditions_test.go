@@ -0,0 +1,164 @@
+package alerts_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/alerts"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Synthetics Conditions", func() {
+	It("Generates help", func() {
+		s := &alerts.SyntheticsConditionExporterCommand{}
+		helpText, err := s.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -p, --policy-id=POLICY-ID,...    Only export the multi-location synthetics
+                                   conditions in this alert policy. May be
+                                   specified multiple times.
+  -n, --name-like=STRING           Only export multi-location synthetics
+                                   conditions whose names contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		s := &alerts.SyntheticsConditionExporterCommand{}
+		info, err := s.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-synthetics-alert-conditions"))
+		Expect(info.Version).To(Equal(plugin.FromString(alerts.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *alerts.SyntheticsConditionExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = alerts.NewSyntheticsConditionExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "1788330", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		It("Exports the conditions in every policy", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "production_checkout", ID: "1001"},
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "synthetics", ID: "1004"},
+				plugin.ImportDirective{Resource: "newrelic_synthetics_multilocation_alert_condition", Name: "checkout_monitors_failing", ID: "1001:3001"},
+				plugin.ImportDirective{Resource: "newrelic_synthetics_multilocation_alert_condition", Name: "synthetics_down", ID: "1004:3002"},
+			))
+
+			for _, directive := range resp.Directives {
+				Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
+			}
+		})
+
+		It("Refers to monitors exported to the same directory", func() {
+			// the synthetics exporter records the monitors it exports by GUID
+			names, err := internal.LoadResourceNames(outputDirectory)
+			Expect(err).NotTo(HaveOccurred())
+			names.Record(plugin.ImportDirective{
+				Resource: "newrelic_synthetics_step_monitor",
+				Name:     names.Reserve("newrelic_synthetics_step_monitor", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGMxOWIyYWIzLWU0ZjktNDAxNC05NDgyLWZmNTkzYjZjM2RmOA", "Monitor Name 9", "monitor"),
+				ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfGMxOWIyYWIzLWU0ZjktNDAxNC05NDgyLWZmNTkzYjZjM2RmOA",
+			})
+			Expect(names.Save(outputDirectory)).To(Succeed())
+
+			export("-p", "1001")
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "checkout_monitors_failing.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_synthetics_multilocation_alert_condition" "checkout_monitors_failing" {
+  policy_id                    = newrelic_alert_policy.production_checkout.id
+  name                         = "Checkout Monitors Failing"
+  enabled                      = true
+  runbook_url                  = "https://runbooks.example.com/synthetics"
+  violation_time_limit_seconds = 3600
+  entities                     = [newrelic_synthetics_step_monitor.monitor_name_9.id, "MTc4ODMzMHxTWU5USHxNT05JVE9SfDBlMWYyYTNiLTRjNWQtNGU2Zi04YTliLTBjMWQyZTNmNGE1Yg"]
+
+  critical {
+    threshold = 2
+  }
+
+  warning {
+    threshold = 1
+  }
+}
+`))
+		})
+
+		It("Refers to monitors that weren't exported by GUID", func() {
+			export("-p", "1004")
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "synthetics_down.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_synthetics_multilocation_alert_condition" "synthetics_down" {
+  policy_id                    = newrelic_alert_policy.synthetics.id
+  name                         = "Synthetics Down"
+  enabled                      = false
+  violation_time_limit_seconds = 86400
+  entities                     = ["MTc4ODMzMHxTWU5USHxNT05JVE9SfGY5ZjIwMzY5LTEwMzMtNDdmMy05ODBhLTY3ZGVkNTcxOWYxYQ"]
+
+  critical {
+    threshold = 3
+  }
+}
+`))
+		})
+
+		It("Filters conditions by name", func() {
+			resp := export("-n", "down")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_alert_policy", Name: "synthetics", ID: "1004"},
+				plugin.ImportDirective{Resource: "newrelic_synthetics_multilocation_alert_condition", Name: "synthetics_down", ID: "1004:3002"},
+			))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package applications_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestApplications(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Applications Suite")
+}
@@ -0,0 +1,142 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type BrowserApplicationExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	NameLike        string `short:"n" help:"Only export browser applications whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewBrowserApplicationExporterCommand(options ...newrelic.ConfigOption) *BrowserApplicationExporterCommand {
+	return &BrowserApplicationExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (b *BrowserApplicationExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(b)
+}
+
+func (b *BrowserApplicationExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-browser-applications",
+		Description: "Export New Relic Browser Applications from the specified New Relic Account. Browser applications served by an APM application are configured by its settings, which newrelic-application-settings exports",
+		Summary:     "Export New Relic Browser Applications from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (b *BrowserApplicationExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(b)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	b.nrClient, err = b.NewClient(b.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	b.outputDirectory = request.OutputDirectory
+
+	b.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	entities, err := searchApplications(ctx, &b.nrClient.NerdGraph, b.AccountID)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	applications := []ApplicationEntity{}
+	for _, entity := range entities {
+		if entity.Domain == domainBrowser && strings.Contains(strings.ToLower(entity.Name), strings.ToLower(b.NameLike)) {
+			applications = append(applications, entity)
+		}
+	}
+
+	if err := b.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, application := range applications {
+		if application.ServingApmApplicationID == nil {
+			b.resourceNames.Reserve(tfBrowserApplicationType, application.GUID, application.Name, "browser_application")
+		}
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, b.ParallelWorkers, applications, b.exportApplication)
+
+	b.resourceNames.Record(directives...)
+	if err := b.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return b.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+func (b *BrowserApplicationExporterCommand) exportApplication(ctx context.Context, application ApplicationEntity) (plugin.ImportDirective, error) {
+	if application.ServingApmApplicationID != nil {
+		b.logger.Info("WARN:", hclog.Fmt("browser application %q is served by APM application %d, and is configured by its settings. Skipping", application.Name, *application.ServingApmApplicationID))
+		return plugin.ImportDirective{}, internal.ErrSkip
+	}
+
+	b.logger.Info("Exporting Browser Application", "name", application.Name)
+
+	var response BrowserSettingsResponse
+	if err := b.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getBrowserSettings, map[string]any{"guid": application.GUID}, &response); err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error querying NerdGraph for the settings of browser application %q: %w", application.Name, err)
+	}
+	monitoring := response.Actor.Entity.BrowserSettings.BrowserMonitoring
+
+	importID := application.GUID
+	tfResourceType := tfBrowserApplicationType
+	tfResourceName := b.resourceNames.Reserve(tfResourceType, importID, application.Name, "browser_application")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(application.Name))
+	body.SetAttributeValue("cookies_enabled", cty.BoolVal(monitoring.Privacy.CookiesEnabled))
+	body.SetAttributeValue("distributed_tracing_enabled", cty.BoolVal(monitoring.DistributedTracing.Enabled))
+
+	if internal.IndexOf(monitoring.Loader, browserLoaderTypes) >= 0 {
+		body.SetAttributeValue("loader_type", cty.StringVal(monitoring.Loader))
+	} else {
+		b.logger.Info("WARN:", hclog.Fmt("browser application %q uses the %s loader, which terraform can't configure. Leaving the loader unset", application.Name, monitoring.Loader))
+	}
+
+	directive, err := internal.WriteResourceFile(b.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering browser application %q: %w", application.Name, err)
+	}
+
+	return directive, nil
+}
@@ -0,0 +1,121 @@
+package applications_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/applications"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Browser Applications", func() {
+	It("Generates help", func() {
+		b := &applications.BrowserApplicationExporterCommand{}
+		helpText, err := b.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -n, --name-like=STRING           Only export browser applications whose names
+                                   contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		b := &applications.BrowserApplicationExporterCommand{}
+		info, err := b.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-browser-applications"))
+		Expect(info.Version).To(Equal(plugin.FromString(applications.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *applications.BrowserApplicationExporterCommand
+			outputDirectory string
+			searchQuery     string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer(&searchQuery)
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = applications.NewBrowserApplicationExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports standalone browser applications, skipping the ones APM applications serve", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_browser_application", Name: "marketing_site", ID: marketingGUID},
+				plugin.ImportDirective{Resource: "newrelic_browser_application", Name: "docs_site", ID: docsGUID},
+			))
+
+			Expect(readFile("marketing_site.tf")).To(Equal(`resource "newrelic_browser_application" "marketing_site" {
+  name                        = "Marketing Site"
+  cookies_enabled             = false
+  distributed_tracing_enabled = true
+  loader_type                 = "SPA"
+}
+`))
+			Expect(readFile("docs_site.tf")).To(Equal(`resource "newrelic_browser_application" "docs_site" {
+  name                        = "Docs Site"
+  cookies_enabled             = true
+  distributed_tracing_enabled = false
+}
+`))
+		})
+
+		It("Filters applications by name", func() {
+			resp := export("-n", "docs")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_browser_application", Name: "docs_site", ID: docsGUID},
+			))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package applications
+
+const (
+	tfApplicationSettingsType = "newrelic_application_settings"
+	tfBrowserApplicationType  = "newrelic_browser_application"
+
+	domainAPM     = "APM"
+	domainBrowser = "BROWSER"
+
+	// applications are searched for by account rather than with a locator query, since the settings of an APM
+	// application depend on the browser application monitoring it
+	applicationsQuery = "accountId = %d AND domain IN ('APM', 'BROWSER') AND type = 'APPLICATION'"
+
+	getApplications = `query($query: String!, $cursor: String) {
+	actor {
+		entitySearch(query: $query) {
+			results(cursor: $cursor) {
+				nextCursor
+				entities {
+					guid
+					name
+					domain
+					... on ApmApplicationEntityOutline {
+						applicationId
+					}
+					... on BrowserApplicationEntityOutline {
+						applicationId
+						servingApmApplicationId
+						settings {
+							apdexTarget
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+	getApmSettings = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on ApmApplicationEntity {
+				apmSettings {
+					apmConfig {
+						apdexTarget
+						useServerSideConfig
+					}
+					transactionTracer {
+						enabled
+						transactionThresholdType
+						transactionThresholdValue
+						stackTraceThreshold
+						recordSql
+						explainEnabled
+						explainThresholdType
+						explainThresholdValue
+					}
+				}
+			}
+		}
+	}
+}
+`
+	getBrowserSettings = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on BrowserApplicationEntity {
+				browserSettings {
+					browserMonitoring {
+						loader
+						distributedTracing {
+							enabled
+						}
+						privacy {
+							cookiesEnabled
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
+
+// these should be treated as constants as well, but Go
+// does not allow map or slice types to be constant
+var (
+	// browserLoaderTypes are the browser agent loaders terraform can configure
+	browserLoaderTypes = []string{"SPA", "PRO", "LITE"}
+)
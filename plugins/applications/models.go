@@ -0,0 +1,67 @@
+package applications
+
+import (
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+// ApplicationEntity is an APM or browser application found by entity search. Browser applications injected by
+// an APM agent are served by that APM application
+type ApplicationEntity struct {
+	GUID                    string `json:"guid"`
+	Name                    string `json:"name"`
+	Domain                  string `json:"domain"`
+	ApplicationID           int    `json:"applicationId"`
+	ServingApmApplicationID *int   `json:"servingApmApplicationId"`
+	Settings                *struct {
+		ApdexTarget float64 `json:"apdexTarget"`
+	} `json:"settings"`
+}
+
+type ApplicationSearchResponse = internal.EntitySearchResponse[ApplicationEntity]
+
+type ApmSettingsResponse struct {
+	Actor struct {
+		Entity struct {
+			ApmSettings ApmSettings `json:"apmSettings"`
+		} `json:"entity"`
+	} `json:"actor"`
+}
+
+type ApmSettings struct {
+	ApmConfig struct {
+		ApdexTarget         float64 `json:"apdexTarget"`
+		UseServerSideConfig bool    `json:"useServerSideConfig"`
+	} `json:"apmConfig"`
+	TransactionTracer *TransactionTracer `json:"transactionTracer"`
+}
+
+type TransactionTracer struct {
+	Enabled                   bool     `json:"enabled"`
+	TransactionThresholdType  string   `json:"transactionThresholdType"`
+	TransactionThresholdValue *float64 `json:"transactionThresholdValue"`
+	StackTraceThreshold       *float64 `json:"stackTraceThreshold"`
+	RecordSQL                 string   `json:"recordSql"`
+	ExplainEnabled            bool     `json:"explainEnabled"`
+	ExplainThresholdType      string   `json:"explainThresholdType"`
+	ExplainThresholdValue     *float64 `json:"explainThresholdValue"`
+}
+
+type BrowserSettingsResponse struct {
+	Actor struct {
+		Entity struct {
+			BrowserSettings BrowserSettings `json:"browserSettings"`
+		} `json:"entity"`
+	} `json:"actor"`
+}
+
+type BrowserSettings struct {
+	BrowserMonitoring struct {
+		Loader             string `json:"loader"`
+		DistributedTracing struct {
+			Enabled bool `json:"enabled"`
+		} `json:"distributedTracing"`
+		Privacy struct {
+			CookiesEnabled bool `json:"cookiesEnabled"`
+		} `json:"privacy"`
+	} `json:"browserMonitoring"`
+}
@@ -0,0 +1,193 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type ApplicationSettingsExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	NameLike        string `short:"n" help:"Only export the settings of APM applications whose names contain this text."`
+	browserApps     map[int]ApplicationEntity
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewApplicationSettingsExporterCommand(options ...newrelic.ConfigOption) *ApplicationSettingsExporterCommand {
+	return &ApplicationSettingsExporterCommand{
+		browserApps:     map[int]ApplicationEntity{},
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (a *ApplicationSettingsExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(a)
+}
+
+func (a *ApplicationSettingsExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-application-settings",
+		Description: "Export the settings of New Relic APM Applications, such as their apdex thresholds and transaction tracers, from the specified New Relic Account",
+		Summary:     "Export New Relic APM Application Settings from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (a *ApplicationSettingsExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(a)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	a.nrClient, err = a.NewClient(a.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	a.outputDirectory = request.OutputDirectory
+
+	a.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	entities, err := searchApplications(ctx, &a.nrClient.NerdGraph, a.AccountID)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// real user monitoring is enabled on an APM application when the agent injects a browser application
+	// into its pages, so the browser applications are indexed by the APM application serving them
+	applications := []ApplicationEntity{}
+	for _, entity := range entities {
+		switch {
+		case entity.Domain == domainBrowser && entity.ServingApmApplicationID != nil:
+			a.browserApps[*entity.ServingApmApplicationID] = entity
+		case entity.Domain == domainAPM && strings.Contains(strings.ToLower(entity.Name), strings.ToLower(a.NameLike)):
+			applications = append(applications, entity)
+		}
+	}
+
+	if err := a.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, application := range applications {
+		a.resourceNames.Reserve(tfApplicationSettingsType, application.GUID, application.Name, "application")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, a.ParallelWorkers, applications, a.exportSettings)
+
+	a.resourceNames.Record(directives...)
+	if err := a.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return a.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+func (a *ApplicationSettingsExporterCommand) exportSettings(ctx context.Context, application ApplicationEntity) (plugin.ImportDirective, error) {
+	a.logger.Info("Exporting Application Settings", "name", application.Name)
+
+	var response ApmSettingsResponse
+	if err := a.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getApmSettings, map[string]any{"guid": application.GUID}, &response); err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error querying NerdGraph for the settings of application %q: %w", application.Name, err)
+	}
+	settings := response.Actor.Entity.ApmSettings
+
+	importID := application.GUID
+	tfResourceType := tfApplicationSettingsType
+	tfResourceName := a.resourceNames.Reserve(tfResourceType, importID, application.Name, "application")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("guid", cty.StringVal(application.GUID))
+	body.SetAttributeValue("name", cty.StringVal(application.Name))
+	body.SetAttributeValue("app_apdex_threshold", cty.NumberFloatVal(settings.ApmConfig.ApdexTarget))
+
+	browserApp, rumEnabled := a.browserApps[application.ApplicationID]
+	if rumEnabled && browserApp.Settings != nil {
+		body.SetAttributeValue("end_user_apdex_threshold", cty.NumberFloatVal(browserApp.Settings.ApdexTarget))
+	}
+	body.SetAttributeValue("enable_real_user_monitoring", cty.BoolVal(rumEnabled))
+	body.SetAttributeValue("enable_server_side_config", cty.BoolVal(settings.ApmConfig.UseServerSideConfig))
+
+	if tracer := settings.TransactionTracer; tracer != nil && tracer.Enabled {
+		renderTransactionTracer(body, tracer)
+	}
+
+	directive, err := internal.WriteResourceFile(a.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering the settings of application %q: %w", application.Name, err)
+	}
+
+	return directive, nil
+}
+
+func renderTransactionTracer(body *hclwrite.Body, tracer *TransactionTracer) {
+	body.AppendNewline()
+	tracerBlock := body.AppendNewBlock("transaction_tracer", nil)
+	if tracer.TransactionThresholdType != "" {
+		tracerBlock.Body().SetAttributeValue("transaction_threshold_type", cty.StringVal(tracer.TransactionThresholdType))
+	}
+	if tracer.TransactionThresholdValue != nil {
+		tracerBlock.Body().SetAttributeValue("transaction_threshold_value", cty.NumberFloatVal(*tracer.TransactionThresholdValue))
+	}
+	if tracer.StackTraceThreshold != nil {
+		tracerBlock.Body().SetAttributeValue("stack_trace_threshold_value", cty.NumberFloatVal(*tracer.StackTraceThreshold))
+	}
+
+	if tracer.ExplainEnabled {
+		explain := tracerBlock.Body().AppendNewBlock("explain_query_plans", nil)
+		if tracer.ExplainThresholdType != "" {
+			explain.Body().SetAttributeValue("query_plan_threshold_type", cty.StringVal(tracer.ExplainThresholdType))
+		}
+		if tracer.ExplainThresholdValue != nil {
+			explain.Body().SetAttributeValue("query_plan_threshold_value", cty.NumberFloatVal(*tracer.ExplainThresholdValue))
+		}
+	}
+
+	if tracer.RecordSQL != "" {
+		tracerBlock.Body().AppendNewBlock("sql", nil).Body().SetAttributeValue("record_sql", cty.StringVal(tracer.RecordSQL))
+	}
+}
+
+// searchApplications finds the APM and browser applications in the account. Both commands need both domains:
+// browser applications served by an APM application are configured by the APM application's settings
+func searchApplications(ctx context.Context, client internal.NerdGraphClient, accountID int) ([]ApplicationEntity, error) {
+	entities := []ApplicationEntity{}
+	queryVariables := map[string]any{"query": fmt.Sprintf(applicationsQuery, accountID)}
+	_, err := internal.SearchEntities(ctx, client, getApplications, queryVariables, 0, func(page []ApplicationEntity) bool {
+		entities = append(entities, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for applications: %w", err)
+	}
+
+	return entities, nil
+}
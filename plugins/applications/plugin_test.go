@@ -0,0 +1,199 @@
+package applications_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/applications"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+const (
+	checkoutGUID  = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+	inventoryGUID = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDI"
+	marketingGUID = "NTY3ODl8QlJPV1NFUnxBUFBMSUNBVElPTnw1MDAy"
+	docsGUID      = "NTY3ODl8QlJPV1NFUnxBUFBMSUNBVElPTnw1MDAz"
+)
+
+var _ = Describe("Application Settings", func() {
+	It("Generates help", func() {
+		a := &applications.ApplicationSettingsExporterCommand{}
+		helpText, err := a.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -n, --name-like=STRING           Only export the settings of APM applications
+                                   whose names contain this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		a := &applications.ApplicationSettingsExporterCommand{}
+		info, err := a.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-application-settings"))
+		Expect(info.Version).To(Equal(plugin.FromString(applications.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *applications.ApplicationSettingsExporterCommand
+			outputDirectory string
+			searchQuery     string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer(&searchQuery)
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = applications.NewApplicationSettingsExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports the settings of every APM application in the account", func() {
+			resp := export()
+			Expect(searchQuery).To(Equal("accountId = 56789 AND domain IN ('APM', 'BROWSER') AND type = 'APPLICATION'"))
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_application_settings", Name: "checkout_service", ID: checkoutGUID},
+				plugin.ImportDirective{Resource: "newrelic_application_settings", Name: "inventory_service", ID: inventoryGUID},
+			))
+
+			Expect(readFile("checkout_service.tf")).To(Equal(`resource "newrelic_application_settings" "checkout_service" {
+  guid                        = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+  name                        = "Checkout Service"
+  app_apdex_threshold         = 0.5
+  end_user_apdex_threshold    = 3
+  enable_real_user_monitoring = true
+  enable_server_side_config   = true
+
+  transaction_tracer {
+    transaction_threshold_type  = "VALUE"
+    transaction_threshold_value = 1.5
+    stack_trace_threshold_value = 0.5
+    explain_query_plans {
+      query_plan_threshold_type  = "VALUE"
+      query_plan_threshold_value = 0.5
+    }
+    sql {
+      record_sql = "OBFUSCATED"
+    }
+  }
+}
+`))
+			Expect(readFile("inventory_service.tf")).To(Equal(`resource "newrelic_application_settings" "inventory_service" {
+  guid                        = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDI"
+  name                        = "Inventory Service"
+  app_apdex_threshold         = 0.3
+  enable_real_user_monitoring = false
+  enable_server_side_config   = false
+}
+`))
+		})
+
+		It("Filters applications by name", func() {
+			resp := export("-n", "inventory")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_application_settings", Name: "inventory_service", ID: inventoryGUID},
+			))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		Query string `json:"query"`
+		GUID  string `json:"guid"`
+	} `json:"variables"`
+}
+
+func mockNerdGraphServer(searchQuery *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// settings are stored by the application's GUID
+		var fileName string
+		switch {
+		case strings.Contains(request.Query, "entitySearch"):
+			*searchQuery = request.Variables.Query
+			fileName = "testdata/get_applications.json"
+		case strings.Contains(request.Query, "apmSettings"):
+			fileName = filepath.Join("testdata/apm_settings", request.Variables.GUID+".json")
+		case strings.Contains(request.Query, "browserSettings"):
+			fileName = filepath.Join("testdata/browser_settings", request.Variables.GUID+".json")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
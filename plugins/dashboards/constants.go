@@ -0,0 +1,106 @@
+package dashboards
+
+const (
+	tfDashboardType     = "newrelic_one_dashboard"
+	tfDashboardJSONType = "newrelic_one_dashboard_json"
+
+	formatTyped = "typed"
+	formatJSON  = "json"
+
+	// accountIDPlaceholder stands in for the account ID in exported dashboard JSON, and is replaced with the
+	// account_id variable when terraform reads the file
+	accountIDPlaceholder = "${account_id}"
+
+	getDashboards = `query($query: String!, $cursor: String) {
+	actor {
+		entitySearch(query: $query) {
+			results(cursor: $cursor) {
+				nextCursor
+				entities {
+					... on DashboardEntityOutline {
+						guid
+						name
+						dashboardParentGuid
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+	getDashboard = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on DashboardEntity {
+				guid
+				name
+				description
+				permissions
+				pages {
+					guid
+					name
+					description
+					widgets {
+						id
+						title
+						layout {
+							column
+							row
+							width
+							height
+						}
+						visualization {
+							id
+						}
+						rawConfiguration
+					}
+				}
+				variables {
+					name
+					title
+					type
+					isMultiSelection
+					replacementStrategy
+					defaultValues {
+						value {
+							string
+						}
+					}
+					nrqlQuery {
+						accountIds
+						query
+					}
+					items {
+						title
+						value
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
+
+// these should be treated as constants as well, but Go
+// does not allow map or slice types to be constant
+var (
+	// widgetBlocks maps the visualizations the provider has typed widgets for to their block types
+	widgetBlocks = map[string]string{
+		"viz.area":                "widget_area",
+		"viz.bar":                 "widget_bar",
+		"viz.billboard":           "widget_billboard",
+		"viz.bullet":              "widget_bullet",
+		"viz.funnel":              "widget_funnel",
+		"viz.heatmap":             "widget_heatmap",
+		"viz.histogram":           "widget_histogram",
+		"viz.json":                "widget_json",
+		"viz.line":                "widget_line",
+		"viz.markdown":            "widget_markdown",
+		"viz.pie":                 "widget_pie",
+		"viz.stacked-bar":         "widget_stacked_bar",
+		"viz.table":               "widget_table",
+		"logger.log-table-widget": "widget_log_table",
+	}
+)
@@ -0,0 +1,126 @@
+package dashboards
+
+import (
+	"encoding/json"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+type DashboardOutline struct {
+	GUID                string `json:"guid"`
+	Name                string `json:"name"`
+	DashboardParentGUID string `json:"dashboardParentGuid"`
+}
+
+type DashboardSearchResponse = internal.EntitySearchResponse[DashboardOutline]
+
+type Dashboard struct {
+	GUID        string              `json:"guid"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions string              `json:"permissions"`
+	Pages       []DashboardPage     `json:"pages"`
+	Variables   []DashboardVariable `json:"variables"`
+}
+
+type DashboardPage struct {
+	GUID        string            `json:"guid"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Widgets     []DashboardWidget `json:"widgets"`
+}
+
+type DashboardWidget struct {
+	ID               string              `json:"id"`
+	Title            string              `json:"title"`
+	Layout           WidgetLayout        `json:"layout"`
+	Visualization    WidgetVisualization `json:"visualization"`
+	RawConfiguration json.RawMessage     `json:"rawConfiguration"`
+}
+
+type WidgetLayout struct {
+	Column int64 `json:"column"`
+	Row    int64 `json:"row"`
+	Width  int64 `json:"width"`
+	Height int64 `json:"height"`
+}
+
+type WidgetVisualization struct {
+	ID string `json:"id"`
+}
+
+// DashboardVariable is a variable the dashboard's queries can refer to. Its values are either listed as
+// items, or are the results of a NRQL query
+type DashboardVariable struct {
+	Name                string `json:"name"`
+	Title               string `json:"title"`
+	Type                string `json:"type"`
+	IsMultiSelection    bool   `json:"isMultiSelection"`
+	ReplacementStrategy string `json:"replacementStrategy"`
+	DefaultValues       []struct {
+		Value struct {
+			String string `json:"string"`
+		} `json:"value"`
+	} `json:"defaultValues"`
+	NrqlQuery *struct {
+		AccountIDs []int64 `json:"accountIds"`
+		Query      string  `json:"query"`
+	} `json:"nrqlQuery"`
+	Items []struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+	} `json:"items"`
+}
+
+// WidgetConfiguration is the part of a widget's raw configuration that the typed widgets support
+type WidgetConfiguration struct {
+	NrqlQueries []struct {
+		AccountID  *int64  `json:"accountId"`
+		AccountIDs []int64 `json:"accountIds"`
+		Query      string  `json:"query"`
+	} `json:"nrqlQueries"`
+	Text       string          `json:"text"`
+	Limit      *float64        `json:"limit"`
+	Thresholds json.RawMessage `json:"thresholds"`
+	Legend     *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"legend"`
+	PlatformOptions *struct {
+		IgnoreTimeRange bool `json:"ignoreTimeRange"`
+	} `json:"platformOptions"`
+}
+
+// BillboardThreshold is one of the thresholds a billboard widget is colored by
+type BillboardThreshold struct {
+	AlertSeverity string   `json:"alertSeverity"`
+	Value         *float64 `json:"value"`
+}
+
+// DashboardJSON is the dashboard JSON a newrelic_one_dashboard_json resource is created from, which is the same
+// JSON the New Relic UI imports and exports dashboards as
+type DashboardJSON struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Permissions string              `json:"permissions"`
+	Pages       []DashboardJSONPage `json:"pages"`
+	Variables   any                 `json:"variables,omitempty"`
+}
+
+type DashboardJSONPage struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Widgets     []DashboardJSONWidget `json:"widgets"`
+}
+
+type DashboardJSONWidget struct {
+	Title            string              `json:"title"`
+	Layout           WidgetLayout        `json:"layout"`
+	Visualization    WidgetVisualization `json:"visualization"`
+	RawConfiguration any                 `json:"rawConfiguration"`
+}
+
+type GetDashboardResponse struct {
+	Actor struct {
+		Entity Dashboard `json:"entity"`
+	} `json:"actor"`
+}
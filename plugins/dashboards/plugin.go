@@ -0,0 +1,447 @@
+package dashboards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type DashboardExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	DashboardID     []string `short:"d" xor:"locator" help:"The GUID of a dashboard to export. May be specified multiple times."`
+	LocatorQuery    string   `short:"q" xor:"locator" help:"The query used with NerdGraph to find dashboards to export. By default, every dashboard in the account is exported."`
+	Format          string   `default:"typed" enum:"typed,json" help:"How dashboards are exported. One of typed (newrelic_one_dashboard resources with a block for each widget) or json (newrelic_one_dashboard_json resources, with the dashboard JSON in a file next to each resource)."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewDashboardExporterCommand(options ...newrelic.ConfigOption) *DashboardExporterCommand {
+	return &DashboardExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (d *DashboardExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(d)
+}
+
+func (d *DashboardExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-dashboards",
+		Description: "Export New Relic Dashboards from the specified New Relic Account",
+		Summary:     "Export New Relic Dashboards from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (d *DashboardExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(d)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// like monitors, individual dashboards are found by searching every dashboard in the account and filtering
+	// the results
+	if d.LocatorQuery == "" || len(d.DashboardID) > 0 {
+		d.LocatorQuery = fmt.Sprintf("accountId = %d AND type = 'DASHBOARD'", d.AccountID)
+	}
+
+	ctx := context.Background()
+	d.nrClient, err = d.NewClient(d.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	d.outputDirectory = request.OutputDirectory
+
+	d.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	dashboards, err := d.searchDashboards(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := d.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, dashboard := range dashboards {
+		d.resourceNames.Reserve(d.resourceType(), dashboard.GUID, dashboard.Name, "dashboard")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, d.ParallelWorkers, dashboards, d.exportDashboard)
+
+	d.resourceNames.Record(directives...)
+	if err := d.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return d.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchDashboards runs the locator query against NerdGraph. Each page of a dashboard is an entity of its own,
+// so only the dashboards themselves, which have no parent, are collected
+func (d *DashboardExporterCommand) searchDashboards(ctx context.Context) ([]DashboardOutline, error) {
+	dashboards := []DashboardOutline{}
+	queryVariables := map[string]any{"query": d.LocatorQuery}
+	_, err := internal.SearchEntities(ctx, &d.nrClient.NerdGraph, getDashboards, queryVariables, 0, func(page []DashboardOutline) bool {
+		for _, dashboard := range page {
+			if dashboard.DashboardParentGUID != "" {
+				continue
+			}
+
+			if len(d.DashboardID) > 0 && internal.IndexOf(dashboard.GUID, d.DashboardID) < 0 {
+				continue
+			}
+
+			dashboards = append(dashboards, dashboard)
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for dashboards: %w", err)
+	}
+
+	return dashboards, nil
+}
+
+func (d *DashboardExporterCommand) exportDashboard(ctx context.Context, outline DashboardOutline) (plugin.ImportDirective, error) {
+	d.logger.Info("Exporting Dashboard", "name", outline.Name)
+
+	var response GetDashboardResponse
+	if err := d.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getDashboard, map[string]any{"guid": outline.GUID}, &response); err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error querying NerdGraph for dashboard %q: %w", outline.Name, err)
+	}
+
+	dashboard := response.Actor.Entity
+	tfResourceType := d.resourceType()
+	tfResourceName := d.resourceNames.Reserve(tfResourceType, dashboard.GUID, dashboard.Name, "dashboard")
+
+	var file *hclwrite.File
+	var err error
+	if d.Format == formatJSON {
+		file, err = d.renderDashboardJSON(dashboard, tfResourceName)
+	} else {
+		file, err = d.renderDashboard(dashboard, tfResourceName)
+	}
+	if err != nil {
+		return plugin.ImportDirective{}, err
+	}
+
+	return internal.WriteResourceFile(d.outputDirectory, file, dashboard.GUID, tfResourceType, tfResourceName)
+}
+
+// resourceType returns the type of the resources dashboards are exported as in the chosen format
+func (d *DashboardExporterCommand) resourceType() string {
+	if d.Format == formatJSON {
+		return tfDashboardJSONType
+	}
+
+	return tfDashboardType
+}
+
+// renderDashboard returns a newrelic_one_dashboard resource for the dashboard, with a typed block for each of
+// its widgets
+func (d *DashboardExporterCommand) renderDashboard(dashboard Dashboard, tfResourceName string) (*hclwrite.File, error) {
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfDashboardType, tfResourceName})
+	block.Body().SetAttributeValue("name", cty.StringVal(dashboard.Name))
+	if dashboard.Description != "" {
+		block.Body().SetAttributeValue("description", cty.StringVal(dashboard.Description))
+	}
+	block.Body().SetAttributeValue("permissions", cty.StringVal(strings.ToLower(dashboard.Permissions)))
+
+	for _, page := range dashboard.Pages {
+		block.Body().AppendNewline()
+		pageBlock := block.Body().AppendNewBlock("page", nil)
+		pageBlock.Body().SetAttributeValue("name", cty.StringVal(page.Name))
+		if page.Description != "" {
+			pageBlock.Body().SetAttributeValue("description", cty.StringVal(page.Description))
+		}
+
+		for _, widget := range page.Widgets {
+			if err := d.renderWidget(pageBlock.Body(), dashboard, widget); err != nil {
+				return nil, fmt.Errorf("error rendering widget %q of dashboard %q: %w", widget.Title, dashboard.Name, err)
+			}
+		}
+	}
+
+	for _, variable := range dashboard.Variables {
+		block.Body().AppendNewline()
+		renderVariable(block.Body(), variable)
+	}
+
+	return file, nil
+}
+
+// renderVariable appends a variable block for one of the dashboard's variables. The provider takes the
+// variable's type and replacement strategy in lower case
+func renderVariable(dashboard *hclwrite.Body, variable DashboardVariable) {
+	body := dashboard.AppendNewBlock("variable", nil).Body()
+	body.SetAttributeValue("name", cty.StringVal(variable.Name))
+	body.SetAttributeValue("title", cty.StringVal(variable.Title))
+	body.SetAttributeValue("type", cty.StringVal(strings.ToLower(variable.Type)))
+	if variable.ReplacementStrategy != "" {
+		body.SetAttributeValue("replacement_strategy", cty.StringVal(strings.ToLower(variable.ReplacementStrategy)))
+	}
+	body.SetAttributeValue("is_multi_selection", cty.BoolVal(variable.IsMultiSelection))
+
+	defaultValues := []string{}
+	for _, defaultValue := range variable.DefaultValues {
+		defaultValues = append(defaultValues, defaultValue.Value.String)
+	}
+	if len(defaultValues) > 0 {
+		body.SetAttributeValue("default_values", internal.ToCtyList(defaultValues))
+	}
+
+	for _, item := range variable.Items {
+		itemBlock := body.AppendNewBlock("item", nil)
+		itemBlock.Body().SetAttributeValue("title", cty.StringVal(item.Title))
+		itemBlock.Body().SetAttributeValue("value", cty.StringVal(item.Value))
+	}
+
+	if variable.NrqlQuery != nil {
+		accountIDs := []cty.Value{}
+		for _, id := range variable.NrqlQuery.AccountIDs {
+			accountIDs = append(accountIDs, cty.NumberIntVal(id))
+		}
+
+		query := body.AppendNewBlock("nrql_query", nil)
+		if len(accountIDs) > 0 {
+			query.Body().SetAttributeValue("account_ids", cty.ListVal(accountIDs))
+		}
+		query.Body().SetAttributeValue("query", cty.StringVal(variable.NrqlQuery.Query))
+	}
+}
+
+// renderDashboardJSON writes the dashboard's JSON to <resource name>.json, and returns a
+// newrelic_one_dashboard_json resource that reads it. Queries in the exported account use the account_id
+// variable rather than the account ID, so the dashboard can be promoted to other accounts
+func (d *DashboardExporterCommand) renderDashboardJSON(dashboard Dashboard, tfResourceName string) (*hclwrite.File, error) {
+	dashboardJSON := DashboardJSON{
+		Name:        dashboard.Name,
+		Description: dashboard.Description,
+		Permissions: dashboard.Permissions,
+		Pages:       []DashboardJSONPage{},
+	}
+
+	for _, page := range dashboard.Pages {
+		jsonPage := DashboardJSONPage{
+			Name:        page.Name,
+			Description: page.Description,
+			Widgets:     []DashboardJSONWidget{},
+		}
+
+		for _, widget := range page.Widgets {
+			config, err := d.templateConfiguration(widget.RawConfiguration)
+			if err != nil {
+				return nil, fmt.Errorf("error reading widget %q of dashboard %q: %w", widget.Title, dashboard.Name, err)
+			}
+
+			jsonPage.Widgets = append(jsonPage.Widgets, DashboardJSONWidget{
+				Title:            widget.Title,
+				Layout:           widget.Layout,
+				Visualization:    widget.Visualization,
+				RawConfiguration: config,
+			})
+		}
+
+		dashboardJSON.Pages = append(dashboardJSON.Pages, jsonPage)
+	}
+
+	// variables can query the exported account too
+	if len(dashboard.Variables) > 0 {
+		rawVariables, err := json.Marshal(dashboard.Variables)
+		if err != nil {
+			return nil, err
+		}
+
+		dashboardJSON.Variables, err = d.templateConfiguration(rawVariables)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the variables of dashboard %q: %w", dashboard.Name, err)
+		}
+	}
+
+	jsonFileName := fmt.Sprintf("%s.json", tfResourceName)
+	jsonFile, err := os.Create(filepath.Join(d.outputDirectory, jsonFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer jsonFile.Close()
+
+	// NRQL is full of < and >, which would otherwise be escaped
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dashboardJSON); err != nil {
+		return nil, fmt.Errorf("error writing JSON for dashboard %q: %w", dashboard.Name, err)
+	}
+
+	// json = replace(file("${path.module}/<resource name>.json"), "\"$${account_id}\"", jsonencode(var.account_id))
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfDashboardJSONType, tfResourceName})
+	block.Body().SetAttributeRaw("json", hclwrite.TokensForFunctionCall(
+		"replace",
+		hclwrite.TokensForFunctionCall("file", internal.CreateModulePath(jsonFileName)),
+		hclwrite.TokensForValue(cty.StringVal(strconv.Quote(accountIDPlaceholder))),
+		hclwrite.TokensForFunctionCall("jsonencode", internal.CreateReference("var", "account_id")),
+	))
+
+	return file, nil
+}
+
+// templateConfiguration decodes a widget's raw configuration, or a dashboard's variables, replacing the
+// exported account's ID with accountIDPlaceholder. Queries in other accounts are left alone
+func (d *DashboardExporterCommand) templateConfiguration(rawConfiguration json.RawMessage) (any, error) {
+	if len(rawConfiguration) == 0 || string(rawConfiguration) == "null" {
+		return map[string]any{}, nil
+	}
+
+	var config any
+	decoder := json.NewDecoder(bytes.NewReader(rawConfiguration))
+	decoder.UseNumber()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	return replaceAccountID(config, json.Number(strconv.Itoa(d.AccountID))), nil
+}
+
+// replaceAccountID walks a decoded configuration, replacing accountID wherever it's the value of an accountId
+// field or an element of an accountIds field
+func replaceAccountID(value any, accountID json.Number) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			switch key {
+			case "accountId":
+				if child == accountID {
+					v[key] = accountIDPlaceholder
+				}
+			case "accountIds":
+				if ids, ok := child.([]any); ok {
+					for i, id := range ids {
+						if id == accountID {
+							ids[i] = accountIDPlaceholder
+						}
+					}
+				}
+			default:
+				v[key] = replaceAccountID(child, accountID)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = replaceAccountID(child, accountID)
+		}
+	}
+
+	return value
+}
+
+// renderWidget appends the typed widget block for widget to a page. Widgets the provider has no typed block for
+// are an error, since leaving them out would delete them from the dashboard once it's applied
+func (d *DashboardExporterCommand) renderWidget(page *hclwrite.Body, dashboard Dashboard, widget DashboardWidget) error {
+	blockType, ok := widgetBlocks[widget.Visualization.ID]
+	if !ok {
+		return fmt.Errorf("%s visualizations have no typed widget, export the dashboard with --format json instead", widget.Visualization.ID)
+	}
+
+	config := WidgetConfiguration{}
+	if len(widget.RawConfiguration) > 0 {
+		if err := json.Unmarshal(widget.RawConfiguration, &config); err != nil {
+			return err
+		}
+	}
+
+	page.AppendNewline()
+	body := page.AppendNewBlock(blockType, nil).Body()
+	body.SetAttributeValue("title", cty.StringVal(widget.Title))
+	body.SetAttributeValue("row", cty.NumberIntVal(widget.Layout.Row))
+	body.SetAttributeValue("column", cty.NumberIntVal(widget.Layout.Column))
+	body.SetAttributeValue("width", cty.NumberIntVal(widget.Layout.Width))
+	body.SetAttributeValue("height", cty.NumberIntVal(widget.Layout.Height))
+
+	if widget.Visualization.ID == "viz.markdown" {
+		body.SetAttributeRaw("text", internal.CreateHeredoc(config.Text, "-MARKDOWN", true))
+	}
+
+	if widget.Visualization.ID == "viz.bullet" && config.Limit != nil {
+		body.SetAttributeValue("limit", cty.NumberFloatVal(*config.Limit))
+	}
+
+	if widget.Visualization.ID == "viz.billboard" {
+		// line charts have thresholds too, but they're an object rather than a list, and have no typed attributes
+		thresholds := []BillboardThreshold{}
+		if len(config.Thresholds) > 0 {
+			if err := json.Unmarshal(config.Thresholds, &thresholds); err != nil {
+				return err
+			}
+		}
+
+		for _, threshold := range thresholds {
+			if threshold.Value != nil && (threshold.AlertSeverity == "CRITICAL" || threshold.AlertSeverity == "WARNING") {
+				body.SetAttributeValue(strings.ToLower(threshold.AlertSeverity), cty.NumberFloatVal(*threshold.Value))
+			}
+		}
+	}
+
+	if config.Legend != nil {
+		body.SetAttributeValue("legend_enabled", cty.BoolVal(config.Legend.Enabled))
+	}
+
+	if config.PlatformOptions != nil {
+		body.SetAttributeValue("ignore_time_range", cty.BoolVal(config.PlatformOptions.IgnoreTimeRange))
+	}
+
+	for _, nrqlQuery := range config.NrqlQueries {
+		query := body.AppendNewBlock("nrql_query", nil)
+
+		// the provider only supports a single account per query
+		accountIDs := nrqlQuery.AccountIDs
+		if nrqlQuery.AccountID != nil {
+			accountIDs = []int64{*nrqlQuery.AccountID}
+		}
+
+		if len(accountIDs) > 1 {
+			d.logger.Info("WARN:", hclog.Fmt("a query in widget %q of dashboard %q runs in %d accounts, only the first will be exported", widget.Title, dashboard.Name, len(accountIDs)))
+		}
+
+		if len(accountIDs) > 0 {
+			query.Body().SetAttributeValue("account_id", cty.NumberIntVal(accountIDs[0]))
+		}
+		query.Body().SetAttributeValue("query", cty.StringVal(nrqlQuery.Query))
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package dashboards_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/dashboards"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+const (
+	serviceOverviewGUID = "NTY3ODl8VklafERBU0hCT0FSRHwxMDAx"
+	teamRunbookGUID     = "NTY3ODl8VklafERBU0hCT0FSRHwyMDAx"
+	customChartsGUID    = "NTY3ODl8VklafERBU0hCT0FSRHwzMDAx"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		d := &dashboards.DashboardExporterCommand{}
+		helpText, err := d.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -d, --dashboard-id=DASHBOARD-ID,...
+                                   The GUID of a dashboard to export. May be
+                                   specified multiple times.
+  -q, --locator-query=STRING       The query used with NerdGraph to find
+                                   dashboards to export. By default, every
+                                   dashboard in the account is exported.
+      --format="typed"             How dashboards are exported. One of
+                                   typed (newrelic_one_dashboard resources
+                                   with a block for each widget) or json
+                                   (newrelic_one_dashboard_json resources,
+                                   with the dashboard JSON in a file next to
+                                   each resource).
+`))
+	})
+
+	It("Generates an info", func() {
+		d := &dashboards.DashboardExporterCommand{}
+		info, err := d.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-dashboards"))
+		Expect(info.Version).To(Equal(plugin.FromString(dashboards.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *dashboards.DashboardExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = dashboards.NewDashboardExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		It("Exports every dashboard, but not their pages", func() {
+			resp := export("--format", "json")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard_json", Name: "service_overview", ID: serviceOverviewGUID},
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard_json", Name: "team_runbook", ID: teamRunbookGUID},
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard_json", Name: "custom_charts", ID: customChartsGUID},
+			))
+
+			for _, directive := range resp.Directives {
+				Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
+			}
+		})
+
+		It("Renders pages, typed widgets and variables", func() {
+			export("-d", serviceOverviewGUID)
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "service_overview.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_one_dashboard" "service_overview" {
+  name        = "Service Overview"
+  description = "Golden signals for the checkout service"
+  permissions = "public_read_write"
+
+  page {
+    name = "Overview"
+
+    widget_line {
+      title             = "Throughput"
+      row               = 1
+      column            = 1
+      width             = 4
+      height            = 3
+      legend_enabled    = true
+      ignore_time_range = false
+      nrql_query {
+        account_id = 56789
+        query      = "SELECT rate(count(*), 1 minute) FROM Transaction TIMESERIES"
+      }
+    }
+
+    widget_billboard {
+      title    = "Error Rate"
+      row      = 1
+      column   = 5
+      width    = 4
+      height   = 3
+      warning  = 1
+      critical = 5
+      nrql_query {
+        account_id = 56789
+        query      = "SELECT percentage(count(*), WHERE error IS true) FROM Transaction"
+      }
+    }
+
+    widget_markdown {
+      title  = "About"
+      row    = 1
+      column = 9
+      width  = 4
+      height = 3
+      text   = <<-MARKDOWN
+      # Checkout
+      Owned by the **payments** team.
+      
+      Costs $${var} nothing
+      MARKDOWN
+    }
+  }
+
+  page {
+    name        = "Errors"
+    description = "Errors by host"
+
+    widget_table {
+      title  = "Errors by Host"
+      row    = 1
+      column = 1
+      width  = 6
+      height = 4
+      nrql_query {
+        account_id = 56789
+        query      = "SELECT count(*) FROM TransactionError FACET host"
+      }
+    }
+  }
+
+  variable {
+    name                 = "host"
+    title                = "Host"
+    type                 = "nrql"
+    replacement_strategy = "default"
+    is_multi_selection   = true
+    default_values       = ["checkout-1", "checkout-2"]
+    nrql_query {
+      account_ids = [56789, 98765]
+      query       = "SELECT uniques(host) FROM Transaction"
+    }
+  }
+
+  variable {
+    name                 = "environment"
+    title                = "Environment"
+    type                 = "enum"
+    replacement_strategy = "string"
+    is_multi_selection   = false
+    default_values       = ["production"]
+    item {
+      title = "Production"
+      value = "production"
+    }
+    item {
+      title = "Staging"
+      value = "staging"
+    }
+  }
+}
+`))
+		})
+
+		It("Exports dashboard JSON, with the account ID replaced by a variable", func() {
+			resp := export("-d", serviceOverviewGUID, "--format", "json")
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard_json", Name: "service_overview", ID: serviceOverviewGUID},
+			))
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "service_overview.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`resource "newrelic_one_dashboard_json" "service_overview" {
+  json = replace(file("${path.module}/service_overview.json"), "\"$${account_id}\"", jsonencode(var.account_id))
+}
+`))
+
+			b, err = os.ReadFile(filepath.Join(outputDirectory, "service_overview.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring(`"accountId": "${account_id}"`))
+			Expect(string(b)).To(MatchRegexp(`"accountIds": \[\s+"\$\{account_id\}",\s+98765\s+\]`))
+			Expect(string(b)).To(ContainSubstring(`"text": "# Checkout\nOwned by the **payments** team.\n\nCosts ${var} nothing"`))
+
+			// variables query the exported account through the variable as well
+			Expect(string(b)).To(ContainSubstring(`"nrqlQuery": {
+        "accountIds": [
+          "${account_id}",
+          98765
+        ],
+        "query": "SELECT uniques(host) FROM Transaction"
+      },`))
+			Expect(string(b)).To(MatchRegexp(`"name": "environment",`))
+			Expect(string(b)).To(MatchRegexp(`"items": \[\s+\{\s+"title": "Production",\s+"value": "production"\s+\}`))
+		})
+
+		It("Exports widgets the provider has no typed blocks for as JSON", func() {
+			resp := export("-d", customChartsGUID, "--format", "json")
+			Expect(resp.Directives).To(HaveLen(1))
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "custom_charts.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring(`"id": "nr1-custom-viz.abc123"`))
+		})
+
+		It("Fails to export dashboards with widgets the provider has no typed blocks for", func() {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         []string{"-i", "56789", "-k", "1234"},
+			})
+			Expect(err).To(MatchError(plugin.ErrSomeExportsFailed))
+			Expect(err.Error()).To(ContainSubstring(`error rendering widget "Custom Visualization" of dashboard "Custom Charts": nr1-custom-viz.abc123 visualizations have no typed widget, export the dashboard with --format json instead`))
+
+			// the other dashboards still export, but the one that would lose a widget doesn't
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard", Name: "service_overview", ID: serviceOverviewGUID},
+				plugin.ImportDirective{Resource: "newrelic_one_dashboard", Name: "team_runbook", ID: teamRunbookGUID},
+			))
+			Expect(filepath.Join(outputDirectory, "custom_charts.tf")).NotTo(BeAnExistingFile())
+		})
+
+		It("Renders bullet widgets", func() {
+			resp := export("-d", teamRunbookGUID)
+			Expect(resp.Directives).To(HaveLen(1))
+
+			b, err := os.ReadFile(filepath.Join(outputDirectory, "team_runbook.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring(`permissions = "private"`))
+			Expect(string(b)).To(MatchRegexp(`limit\s+= 1000000`))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		GUID string `json:"guid"`
+	} `json:"variables"`
+}
+
+func mockNerdGraphServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file := ""
+		if strings.Contains(request.Query, "entitySearch") {
+			file = "testdata/get_dashboards.json"
+		} else if strings.Contains(request.Query, "DashboardEntity") {
+			file = fmt.Sprintf("testdata/dashboards/%s.json", request.Variables.GUID)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
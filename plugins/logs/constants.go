@@ -0,0 +1,62 @@
+package logs
+
+const (
+	tfLogParsingRuleType        = "newrelic_log_parsing_rule"
+	tfNrqlDropRuleType          = "newrelic_nrql_drop_rule"
+	tfObfuscationExpressionType = "newrelic_obfuscation_expression"
+	tfObfuscationRuleType       = "newrelic_obfuscation_rule"
+
+	getLogRules = `query($accountID: Int!) {
+	actor {
+		account(id: $accountID) {
+			logConfigurations {
+				parsingRules {
+					id
+					description
+					enabled
+					deleted
+					attribute
+					lucene
+					nrql
+					grok
+				}
+				obfuscationExpressions {
+					id
+					name
+					description
+					regex
+				}
+				obfuscationRules {
+					id
+					name
+					description
+					enabled
+					filter
+					actions {
+						attributes
+						method
+						expression {
+							id
+						}
+					}
+				}
+			}
+			nrqlDropRules {
+				list {
+					error {
+						description
+						reason
+					}
+					rules {
+						id
+						description
+						action
+						nrql
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
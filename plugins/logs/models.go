@@ -0,0 +1,65 @@
+package logs
+
+type LogRulesResponse struct {
+	Actor struct {
+		Account struct {
+			LogConfigurations struct {
+				ParsingRules           []ParsingRule           `json:"parsingRules"`
+				ObfuscationExpressions []ObfuscationExpression `json:"obfuscationExpressions"`
+				ObfuscationRules       []ObfuscationRule       `json:"obfuscationRules"`
+			} `json:"logConfigurations"`
+			NrqlDropRules struct {
+				List struct {
+					Error *struct {
+						Description string `json:"description"`
+						Reason      string `json:"reason"`
+					} `json:"error"`
+					Rules []DropRule `json:"rules"`
+				} `json:"list"`
+			} `json:"nrqlDropRules"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+// ParsingRule is a log parsing rule. Its description is the name shown in the UI
+type ParsingRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Deleted     bool   `json:"deleted"`
+	Attribute   string `json:"attribute"`
+	Lucene      string `json:"lucene"`
+	NRQL        string `json:"nrql"`
+	Grok        string `json:"grok"`
+}
+
+type DropRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Action      string `json:"action"`
+	NRQL        string `json:"nrql"`
+}
+
+type ObfuscationExpression struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Regex       string `json:"regex"`
+}
+
+type ObfuscationRule struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Enabled     bool                    `json:"enabled"`
+	Filter      string                  `json:"filter"`
+	Actions     []ObfuscationRuleAction `json:"actions"`
+}
+
+type ObfuscationRuleAction struct {
+	Attributes []string `json:"attributes"`
+	Method     string   `json:"method"`
+	Expression struct {
+		ID string `json:"id"`
+	} `json:"expression"`
+}
@@ -0,0 +1,268 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type LogRuleExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewLogRuleExporterCommand(options ...newrelic.ConfigOption) *LogRuleExporterCommand {
+	return &LogRuleExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (l *LogRuleExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(l)
+}
+
+func (l *LogRuleExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-log-rules",
+		Description: "Export New Relic Log Parsing Rules, NRQL Drop Rules, and Log Obfuscation Expressions and Rules from the specified New Relic Account",
+		Summary:     "Export New Relic Log Management Rules from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (l *LogRuleExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(l)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	l.nrClient, err = l.NewClient(l.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	l.outputDirectory = request.OutputDirectory
+
+	l.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	// every kind of rule is listed by the same query, none of them are paginated
+	var response LogRulesResponse
+	if err := l.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getLogRules, map[string]any{"accountID": l.AccountID}, &response); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error querying NerdGraph for log rules: %w", err)
+	}
+
+	account := response.Actor.Account
+	if listError := account.NrqlDropRules.List.Error; listError != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error listing NRQL drop rules: %s: %s", listError.Reason, listError.Description)
+	}
+
+	parsingRules := []ParsingRule{}
+	for _, rule := range account.LogConfigurations.ParsingRules {
+		if !rule.Deleted {
+			parsingRules = append(parsingRules, rule)
+		}
+	}
+	dropRules := account.NrqlDropRules.List.Rules
+	expressions := account.LogConfigurations.ObfuscationExpressions
+	obfuscationRules := account.LogConfigurations.ObfuscationRules
+
+	if err := l.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// every name is reserved before anything is rendered, so obfuscation rules can refer to expressions no
+	// matter which order they're exported in
+	for _, rule := range parsingRules {
+		l.resourceNames.Reserve(tfLogParsingRuleType, rule.ID, rule.Description, "parsing_rule")
+	}
+
+	for _, rule := range dropRules {
+		l.resourceNames.Reserve(tfNrqlDropRuleType, l.dropRuleImportID(rule), rule.Description, "drop_rule")
+	}
+
+	for _, expression := range expressions {
+		l.resourceNames.Reserve(tfObfuscationExpressionType, expression.ID, expression.Name, "obfuscation_expression")
+	}
+
+	for _, rule := range obfuscationRules {
+		l.resourceNames.Reserve(tfObfuscationRuleType, rule.ID, rule.Name, "obfuscation_rule")
+	}
+
+	directives, parsingError := internal.ExportInParallel(ctx, l.ParallelWorkers, parsingRules, l.exportParsingRule)
+	dropDirectives, dropError := internal.ExportInParallel(ctx, l.ParallelWorkers, dropRules, l.exportDropRule)
+	expressionDirectives, expressionsError := internal.ExportInParallel(ctx, l.ParallelWorkers, expressions, l.exportObfuscationExpression)
+	obfuscationDirectives, obfuscationError := internal.ExportInParallel(ctx, l.ParallelWorkers, obfuscationRules, l.exportObfuscationRule)
+	directives = append(append(append(directives, dropDirectives...), expressionDirectives...), obfuscationDirectives...)
+	commandError := errors.Join(parsingError, dropError, expressionsError, obfuscationError)
+
+	l.resourceNames.Record(directives...)
+	if err := l.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return l.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+func (l *LogRuleExporterCommand) exportParsingRule(_ context.Context, rule ParsingRule) (plugin.ImportDirective, error) {
+	l.logger.Info("Exporting Log Parsing Rule", "name", rule.Description)
+
+	importID := rule.ID
+	tfResourceType := tfLogParsingRuleType
+	tfResourceName := l.resourceNames.Reserve(tfResourceType, importID, rule.Description, "parsing_rule")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(rule.Description))
+	body.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+	if rule.Attribute != "" {
+		body.SetAttributeValue("attribute", cty.StringVal(rule.Attribute))
+	}
+	body.SetAttributeValue("lucene", cty.StringVal(rule.Lucene))
+	setText(body, "nrql", rule.NRQL, "-NRQL")
+	setText(body, "grok", rule.Grok, "-GROK")
+
+	directive, err := internal.WriteResourceFile(l.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering log parsing rule %q: %w", rule.Description, err)
+	}
+
+	return directive, nil
+}
+
+func (l *LogRuleExporterCommand) exportDropRule(_ context.Context, rule DropRule) (plugin.ImportDirective, error) {
+	l.logger.Info("Exporting NRQL Drop Rule", "name", rule.Description)
+
+	importID := l.dropRuleImportID(rule)
+	tfResourceType := tfNrqlDropRuleType
+	tfResourceName := l.resourceNames.Reserve(tfResourceType, importID, rule.Description, "drop_rule")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	if rule.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(rule.Description))
+	}
+	// NerdGraph reports actions in upper case, but the provider takes them in lower case
+	body.SetAttributeValue("action", cty.StringVal(strings.ToLower(rule.Action)))
+	setText(body, "nrql", rule.NRQL, "-NRQL")
+
+	directive, err := internal.WriteResourceFile(l.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering NRQL drop rule %s: %w", rule.ID, err)
+	}
+
+	return directive, nil
+}
+
+func (l *LogRuleExporterCommand) exportObfuscationExpression(_ context.Context, expression ObfuscationExpression) (plugin.ImportDirective, error) {
+	l.logger.Info("Exporting Obfuscation Expression", "name", expression.Name)
+
+	importID := expression.ID
+	tfResourceType := tfObfuscationExpressionType
+	tfResourceName := l.resourceNames.Reserve(tfResourceType, importID, expression.Name, "obfuscation_expression")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(expression.Name))
+	if expression.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(expression.Description))
+	}
+	body.SetAttributeValue("regex", cty.StringVal(expression.Regex))
+
+	directive, err := internal.WriteResourceFile(l.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering obfuscation expression %q: %w", expression.Name, err)
+	}
+
+	return directive, nil
+}
+
+func (l *LogRuleExporterCommand) exportObfuscationRule(_ context.Context, rule ObfuscationRule) (plugin.ImportDirective, error) {
+	l.logger.Info("Exporting Obfuscation Rule", "name", rule.Name)
+
+	importID := rule.ID
+	tfResourceType := tfObfuscationRuleType
+	tfResourceName := l.resourceNames.Reserve(tfResourceType, importID, rule.Name, "obfuscation_rule")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(rule.Name))
+	if rule.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(rule.Description))
+	}
+	body.SetAttributeValue("enabled", cty.BoolVal(rule.Enabled))
+	setText(body, "filter", rule.Filter, "-NRQL")
+
+	for _, action := range rule.Actions {
+		body.AppendNewline()
+		actionBlock := body.AppendNewBlock("action", nil)
+		actionBlock.Body().SetAttributeValue("attribute", internal.ToCtyList(action.Attributes))
+		actionBlock.Body().SetAttributeRaw("expression_id", l.expressionReference(action.Expression.ID))
+		actionBlock.Body().SetAttributeValue("method", cty.StringVal(action.Method))
+	}
+
+	directive, err := internal.WriteResourceFile(l.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering obfuscation rule %q: %w", rule.Name, err)
+	}
+
+	return directive, nil
+}
+
+// expressionReference returns a reference to the obfuscation expression with the given ID. Every expression
+// in the account is exported along with the rules, so the expression's ID is only used if it wasn't
+func (l *LogRuleExporterCommand) expressionReference(id string) hclwrite.Tokens {
+	name, ok := l.resourceNames.Lookup(tfObfuscationExpressionType, id)
+	if !ok {
+		l.logger.Info("WARN:", hclog.Fmt("obfuscation expression %s was not exported, referring to it by ID", id))
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+
+	return internal.CreateReference(tfObfuscationExpressionType, name, "id")
+}
+
+// dropRuleImportID returns the ID terraform imports a NRQL drop rule with, <account id>:<rule id>
+func (l *LogRuleExporterCommand) dropRuleImportID(rule DropRule) string {
+	return fmt.Sprintf("%d:%s", l.AccountID, rule.ID)
+}
+
+// setText sets an attribute holding a Grok pattern or NRQL query, which are easier to read as heredocs when
+// they span several lines
+func setText(body *hclwrite.Body, name string, text string, heredocMarker string) {
+	if strings.Contains(text, "\n") {
+		body.SetAttributeRaw(name, internal.CreateHeredoc(text, heredocMarker, true))
+		return
+	}
+
+	body.SetAttributeValue(name, cty.StringVal(text))
+}
@@ -0,0 +1,192 @@
+package logs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/logs"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		l := &logs.LogRuleExporterCommand{}
+		helpText, err := l.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+`))
+	})
+
+	It("Generates an info", func() {
+		l := &logs.LogRuleExporterCommand{}
+		info, err := l.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-log-rules"))
+		Expect(info.Version).To(Equal(plugin.FromString(logs.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *logs.LogRuleExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = logs.NewLogRuleExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports every kind of log rule, leaving out deleted parsing rules", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_log_parsing_rule", Name: "nginx_access", ID: "11"},
+				plugin.ImportDirective{Resource: "newrelic_log_parsing_rule", Name: "checkout_errors", ID: "12"},
+				plugin.ImportDirective{Resource: "newrelic_nrql_drop_rule", Name: "drop_debug_logs", ID: "56789:41"},
+				plugin.ImportDirective{Resource: "newrelic_nrql_drop_rule", Name: "drop_rule_03687e1e", ID: "56789:42"},
+				plugin.ImportDirective{Resource: "newrelic_obfuscation_expression", Name: "credit_card", ID: "21"},
+				// New Relic numbers expressions and rules separately, so the same ID can be both
+				plugin.ImportDirective{Resource: "newrelic_obfuscation_rule", Name: "mask_cards", ID: "21"},
+			))
+
+			Expect(readFile("nginx_access.tf")).To(Equal(`resource "newrelic_log_parsing_rule" "nginx_access" {
+  name      = "Nginx Access"
+  enabled   = true
+  attribute = "message"
+  lucene    = "logtype:nginx"
+  nrql      = "SELECT * FROM Log WHERE logtype = 'nginx'"
+  grok      = "%%{IPORHOST:clientip} %%{WORD:method} %%{NUMBER:status:int}"
+}
+`))
+			Expect(readFile("checkout_errors.tf")).To(Equal(`resource "newrelic_log_parsing_rule" "checkout_errors" {
+  name    = "Checkout Errors"
+  enabled = false
+  lucene  = "service:checkout"
+  nrql    = <<-NRQL
+  SELECT * FROM Log
+  WHERE service = 'checkout'
+  AND level = 'error'
+  NRQL
+  grok = <<-GROK
+  %%{TIMESTAMP_ISO8601:timestamp} %%{LOGLEVEL:level}
+  %%{GREEDYDATA:stack}
+  GROK
+}
+`))
+			Expect(readFile("drop_debug_logs.tf")).To(Equal(`resource "newrelic_nrql_drop_rule" "drop_debug_logs" {
+  description = "Drop debug logs"
+  action      = "drop_data"
+  nrql        = "SELECT * FROM Log WHERE level = 'debug'"
+}
+`))
+			Expect(readFile("credit_card.tf")).To(Equal(`resource "newrelic_obfuscation_expression" "credit_card" {
+  name        = "Credit Card"
+  description = "Card numbers"
+  regex       = "\\d{4}-\\d{4}-\\d{4}-\\d{4}"
+}
+`))
+			Expect(readFile("mask_cards.tf")).To(Equal(`resource "newrelic_obfuscation_rule" "mask_cards" {
+  name    = "Mask Cards"
+  enabled = true
+  filter  = "SELECT * FROM Log WHERE service = 'checkout'"
+
+  action {
+    attribute     = ["message", "payload"]
+    expression_id = newrelic_obfuscation_expression.credit_card.id
+    method        = "MASK"
+  }
+}
+`))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query string `json:"query"`
+}
+
+func mockNerdGraphServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !strings.Contains(request.Query, "logConfigurations") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, err := os.ReadFile("testdata/get_log_rules.json")
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
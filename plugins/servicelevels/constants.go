@@ -0,0 +1,64 @@
+package servicelevels
+
+const (
+	tfServiceLevelType = "newrelic_service_level"
+
+	getServiceLevels = `query($query: String!, $cursor: String) {
+	actor {
+		entitySearch(query: $query) {
+			results(cursor: $cursor) {
+				nextCursor
+				entities {
+					guid
+					name
+					serviceLevel {
+						indicators {
+							id
+							guid
+							name
+							description
+							entityGuid
+							events {
+								account {
+									id
+								}
+								validEvents {
+									...serviceLevelEventsQuery
+								}
+								goodEvents {
+									...serviceLevelEventsQuery
+								}
+								badEvents {
+									...serviceLevelEventsQuery
+								}
+							}
+							objectives {
+								name
+								description
+								target
+								timeWindow {
+									rolling {
+										count
+										unit
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+fragment serviceLevelEventsQuery on ServiceLevelEventsQuery {
+	from
+	where
+	select {
+		attribute
+		function
+		threshold
+	}
+}
+`
+)
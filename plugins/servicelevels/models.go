@@ -0,0 +1,57 @@
+package servicelevels
+
+import (
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+// ServiceLevelEntity is an entity along with the service levels defined for it
+type ServiceLevelEntity struct {
+	GUID         string `json:"guid"`
+	Name         string `json:"name"`
+	ServiceLevel *struct {
+		Indicators []ServiceLevelIndicator `json:"indicators"`
+	} `json:"serviceLevel"`
+}
+
+type ServiceLevelSearchResponse = internal.EntitySearchResponse[ServiceLevelEntity]
+
+type ServiceLevelIndicator struct {
+	ID          string                  `json:"id"`
+	GUID        string                  `json:"guid"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	EntityGUID  string                  `json:"entityGuid"`
+	Events      ServiceLevelEvents      `json:"events"`
+	Objectives  []ServiceLevelObjective `json:"objectives"`
+}
+
+type ServiceLevelEvents struct {
+	Account struct {
+		ID int64 `json:"id"`
+	} `json:"account"`
+	ValidEvents *ServiceLevelEventsQuery `json:"validEvents"`
+	GoodEvents  *ServiceLevelEventsQuery `json:"goodEvents"`
+	BadEvents   *ServiceLevelEventsQuery `json:"badEvents"`
+}
+
+type ServiceLevelEventsQuery struct {
+	From   string `json:"from"`
+	Where  string `json:"where"`
+	Select *struct {
+		Attribute string   `json:"attribute"`
+		Function  string   `json:"function"`
+		Threshold *float64 `json:"threshold"`
+	} `json:"select"`
+}
+
+type ServiceLevelObjective struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Target      float64 `json:"target"`
+	TimeWindow  struct {
+		Rolling struct {
+			Count int64  `json:"count"`
+			Unit  string `json:"unit"`
+		} `json:"rolling"`
+	} `json:"timeWindow"`
+}
@@ -0,0 +1,200 @@
+package servicelevels
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type ServiceLevelExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	LocatorQuery    string `short:"q" help:"The query used with NerdGraph to find the entities whose service levels are exported. By default, the service levels of every entity in the account are exported."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewServiceLevelExporterCommand(options ...newrelic.ConfigOption) *ServiceLevelExporterCommand {
+	return &ServiceLevelExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (s *ServiceLevelExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(s)
+}
+
+func (s *ServiceLevelExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-service-levels",
+		Description: "Export New Relic Service Levels from the specified New Relic Account. Synthetic monitors exported to the same directory are referred to by address",
+		Summary:     "Export New Relic Service Levels from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (s *ServiceLevelExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(s)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if s.LocatorQuery == "" {
+		s.LocatorQuery = fmt.Sprintf("accountId = %d", s.AccountID)
+	}
+
+	ctx := context.Background()
+	s.nrClient, err = s.NewClient(s.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	s.outputDirectory = request.OutputDirectory
+
+	s.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	indicators, err := s.searchServiceLevels(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := s.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, indicator := range indicators {
+		s.resourceNames.Reserve(tfServiceLevelType, serviceLevelImportID(indicator), indicator.Name, "service_level")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, s.ParallelWorkers, indicators, s.exportServiceLevel)
+
+	s.resourceNames.Record(directives...)
+	if err := s.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return s.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchServiceLevels runs the locator query against NerdGraph. Service levels aren't searched for directly,
+// they're read from the entities they measure
+func (s *ServiceLevelExporterCommand) searchServiceLevels(ctx context.Context) ([]ServiceLevelIndicator, error) {
+	indicators := []ServiceLevelIndicator{}
+	queryVariables := map[string]any{"query": s.LocatorQuery}
+	_, err := internal.SearchEntities(ctx, &s.nrClient.NerdGraph, getServiceLevels, queryVariables, 0, func(page []ServiceLevelEntity) bool {
+		for _, entity := range page {
+			if entity.ServiceLevel != nil {
+				indicators = append(indicators, entity.ServiceLevel.Indicators...)
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for service levels: %w", err)
+	}
+
+	return indicators, nil
+}
+
+func (s *ServiceLevelExporterCommand) exportServiceLevel(_ context.Context, indicator ServiceLevelIndicator) (plugin.ImportDirective, error) {
+	s.logger.Info("Exporting Service Level", "name", indicator.Name)
+
+	importID := serviceLevelImportID(indicator)
+	tfResourceType := tfServiceLevelType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, importID, indicator.Name, "service_level")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	// service levels on synthetic monitors exported to the same directory refer to the monitor resource
+	body.SetAttributeRaw("guid", internal.CreateEntityReference(s.resourceNames, indicator.EntityGUID))
+	body.SetAttributeValue("name", cty.StringVal(indicator.Name))
+	if indicator.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(indicator.Description))
+	}
+
+	body.AppendNewline()
+	events := body.AppendNewBlock("events", nil)
+	events.Body().SetAttributeValue("account_id", cty.NumberIntVal(indicator.Events.Account.ID))
+	renderEventsQuery(events.Body(), "valid_events", indicator.Events.ValidEvents)
+	renderEventsQuery(events.Body(), "good_events", indicator.Events.GoodEvents)
+	renderEventsQuery(events.Body(), "bad_events", indicator.Events.BadEvents)
+
+	for _, objective := range indicator.Objectives {
+		body.AppendNewline()
+		objectiveBlock := body.AppendNewBlock("objective", nil)
+		if objective.Name != "" {
+			objectiveBlock.Body().SetAttributeValue("name", cty.StringVal(objective.Name))
+		}
+		if objective.Description != "" {
+			objectiveBlock.Body().SetAttributeValue("description", cty.StringVal(objective.Description))
+		}
+		objectiveBlock.Body().SetAttributeValue("target", cty.NumberFloatVal(objective.Target))
+
+		rolling := objectiveBlock.Body().AppendNewBlock("time_window", nil).Body().AppendNewBlock("rolling", nil)
+		rolling.Body().SetAttributeValue("count", cty.NumberIntVal(objective.TimeWindow.Rolling.Count))
+		rolling.Body().SetAttributeValue("unit", cty.StringVal(objective.TimeWindow.Rolling.Unit))
+	}
+
+	directive, err := internal.WriteResourceFile(s.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering service level %q: %w", indicator.Name, err)
+	}
+
+	return directive, nil
+}
+
+// renderEventsQuery appends a valid_events, good_events or bad_events block to a service level's events, if
+// the service level has one
+func renderEventsQuery(events *hclwrite.Body, blockType string, query *ServiceLevelEventsQuery) {
+	if query == nil {
+		return
+	}
+
+	events.AppendNewline()
+	body := events.AppendNewBlock(blockType, nil).Body()
+	body.SetAttributeValue("from", cty.StringVal(query.From))
+	if query.Where != "" {
+		body.SetAttributeValue("where", cty.StringVal(query.Where))
+	}
+
+	if query.Select != nil {
+		selectBlock := body.AppendNewBlock("select", nil)
+		if query.Select.Attribute != "" {
+			selectBlock.Body().SetAttributeValue("attribute", cty.StringVal(query.Select.Attribute))
+		}
+		selectBlock.Body().SetAttributeValue("function", cty.StringVal(query.Select.Function))
+		if query.Select.Threshold != nil {
+			selectBlock.Body().SetAttributeValue("threshold", cty.NumberFloatVal(*query.Select.Threshold))
+		}
+	}
+}
+
+// serviceLevelImportID returns the ID terraform imports a service level with,
+// <account id>:<service level id>:<entity guid>
+func serviceLevelImportID(indicator ServiceLevelIndicator) string {
+	return fmt.Sprintf("%d:%s:%s", indicator.Events.Account.ID, indicator.ID, indicator.EntityGUID)
+}
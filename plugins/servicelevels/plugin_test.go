@@ -0,0 +1,230 @@
+package servicelevels_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/servicelevels"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+const (
+	monitorGUID     = "NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDE"
+	applicationGUID = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		s := &servicelevels.ServiceLevelExporterCommand{}
+		helpText, err := s.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -q, --locator-query=STRING       The query used with NerdGraph to find the
+                                   entities whose service levels are exported.
+                                   By default, the service levels of every
+                                   entity in the account are exported.
+`))
+	})
+
+	It("Generates an info", func() {
+		s := &servicelevels.ServiceLevelExporterCommand{}
+		info, err := s.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-service-levels"))
+		Expect(info.Version).To(Equal(plugin.FromString(servicelevels.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *servicelevels.ServiceLevelExporterCommand
+			outputDirectory string
+			searchQuery     string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer(&searchQuery)
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = servicelevels.NewServiceLevelExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports the service levels of every entity in the account", func() {
+			resp := export()
+			Expect(searchQuery).To(Equal("accountId = 56789"))
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_service_level", Name: "checkout_ping_latency", ID: "56789:101:" + monitorGUID},
+				plugin.ImportDirective{Resource: "newrelic_service_level", Name: "checkout_service_errors", ID: "56789:102:" + applicationGUID},
+			))
+
+			Expect(readFile("checkout_service_errors.tf")).To(Equal(`resource "newrelic_service_level" "checkout_service_errors" {
+  guid = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+  name = "Checkout Service Errors"
+
+  events {
+    account_id = 56789
+
+    valid_events {
+      from  = "Transaction"
+      where = "appName = 'checkout'"
+    }
+
+    bad_events {
+      from  = "TransactionError"
+      where = "appName = 'checkout' AND error.expected IS FALSE"
+      select {
+        function = "COUNT"
+      }
+    }
+  }
+
+  objective {
+    name        = "Monthly"
+    description = "Error budget for the month"
+    target      = 99.9
+    time_window {
+      rolling {
+        count = 28
+        unit  = "DAY"
+      }
+    }
+  }
+}
+`))
+		})
+
+		It("Uses the locator query", func() {
+			export("-q", "name = 'Checkout Service'")
+			Expect(searchQuery).To(Equal("name = 'Checkout Service'"))
+		})
+
+		It("Refers to synthetic monitors exported to the same directory", func() {
+			names := fmt.Sprintf(`{"names":{"newrelic_synthetics_monitor":{"%[1]s":"checkout_ping"}},"exported":{"newrelic_synthetics_monitor":["%[1]s"]}}`, monitorGUID)
+			Expect(os.WriteFile(filepath.Join(outputDirectory, ".resource_names.json"), []byte(names), 0644)).To(Succeed())
+
+			export()
+			Expect(readFile("checkout_ping_latency.tf")).To(Equal(`resource "newrelic_service_level" "checkout_ping_latency" {
+  guid        = newrelic_synthetics_monitor.checkout_ping.id
+  name        = "Checkout Ping Latency"
+  description = "Checks that finish within a second"
+
+  events {
+    account_id = 56789
+
+    valid_events {
+      from  = "SyntheticCheck"
+      where = "entityGuid = 'NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDE'"
+    }
+
+    good_events {
+      from  = "SyntheticCheck"
+      where = "entityGuid = 'NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDE' AND duration < 1000"
+    }
+  }
+
+  objective {
+    target = 99.5
+    time_window {
+      rolling {
+        count = 7
+        unit  = "DAY"
+      }
+    }
+  }
+}
+`))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		Query string `json:"query"`
+	} `json:"variables"`
+}
+
+func mockNerdGraphServer(searchQuery *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !strings.Contains(request.Query, "serviceLevel") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		*searchQuery = request.Variables.Query
+		data, err := os.ReadFile("testdata/get_service_levels.json")
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
@@ -1,14 +1,24 @@
 package synthetics
 
 const (
-	tfSimpleMonitorType = "newrelic_synthetics_monitor"
-	tfStepMonitorType   = "newrelic_synthetics_monitor_step"
-	tfScriptMonitorType = "newrelic_synthetics_script_monitor"
+	tfSimpleMonitorType      = "newrelic_synthetics_monitor"
+	tfStepMonitorType        = "newrelic_synthetics_step_monitor"
+	tfScriptMonitorType      = "newrelic_synthetics_script_monitor"
+	tfCertCheckMonitorType   = "newrelic_synthetics_cert_check_monitor"
+	tfBrokenLinksMonitorType = "newrelic_synthetics_broken_links_monitor"
+	tfPrivateLocationType    = "newrelic_synthetics_private_location"
+	tfSecureCredentialType   = "newrelic_synthetics_secure_credential"
 
-	getMonitors = `query($query: String!) {
+	privateLocationQuery = "domain = 'SYNTH' AND type = 'PRIVATE_LOCATION'"
+
+	runtimeTypeNodeAPI       = "NODE_API"
+	scriptLanguageJavascript = "JAVASCRIPT"
+
+	getMonitors = `query($query: String!, $cursor: String) {
   actor {
     entitySearch(query: $query) {
-      results {
+      results(cursor: $cursor) {
+        nextCursor
         entities {
           ... on SyntheticMonitorEntityOutline {
             guid
@@ -30,6 +40,24 @@ const (
     }
   }
 }
+`
+	getPrivateLocations = `query($query: String!, $cursor: String) {
+  actor {
+    entitySearch(query: $query) {
+      results(cursor: $cursor) {
+        nextCursor
+        entities {
+          guid
+          name
+          tags {
+            key
+            values
+          }
+        }
+      }
+    }
+  }
+}
 `
 	getSteps = `query($accountID: Int!, $guid: EntityGuid!) {
   actor {
@@ -57,25 +85,6 @@ const (
 	  }
 	}
 }
-`
-
-	providerTF = `# Configure the New Relic provider
-provider "newrelic" {
-	account_id = var.account_id
-	api_key    = var.api_key
-	region     = "US"
-}
-
-variable "account_id" {
-	type        = number
-	description = "The New Relic Account ID"
-	default     = %d
-}
-
-variable "api_key" {
-	type        = string
-	description = "The New Relic API Key"
-}
 `
 )
 
@@ -94,8 +103,53 @@ var (
 		"1440": "EVERY_DAY",
 	}
 
+	// monitorResourceTypes maps each supported monitor type to the type of the resource it is
+	// exported as
+	monitorResourceTypes = map[string]string{
+		"SIMPLE":         tfSimpleMonitorType,
+		"BROWSER":        tfSimpleMonitorType,
+		"STEP_MONITOR":   tfStepMonitorType,
+		"SCRIPT_BROWSER": tfScriptMonitorType,
+		"SCRIPT_API":     tfScriptMonitorType,
+		"CERT_CHECK":     tfCertCheckMonitorType,
+		"BROKEN_LINKS":   tfBrokenLinksMonitorType,
+	}
+
+	// simpleMonitorOptions and browserMonitorOptions map the tags holding a monitor's boolean
+	// options to the attributes they set. These are slices rather than maps so the attributes
+	// are always rendered in the same order
+	simpleMonitorOptions = []monitorOption{
+		{tag: "bypassHeadRequest", attribute: "bypass_head_request"},
+		{tag: "redirectIsFailure", attribute: "treat_redirect_as_failure"},
+		{tag: "useTlsValidation", attribute: "verify_ssl"},
+	}
+
+	browserMonitorOptions = []monitorOption{
+		{tag: "enableScreenshotOnFailureAndScript", attribute: "enable_screenshot_on_failure_and_script"},
+		{tag: "useTlsValidation", attribute: "verify_ssl"},
+	}
+
+	// regionMap maps the label of each public location, as it appears in a monitor's publicLocation
+	// tag, to the name the provider uses for it. Monitors running in a location missing from here fail
+	// to export, so it has to be kept up to date as New Relic adds locations
 	regionMap = map[string]string{
+		"Cape Town, ZA":          "AF_SOUTH_1",
+		"Hong Kong, HK":          "AP_EAST_1",
+		"Mumbai, IN":             "AP_SOUTH_1",
+		"Singapore, SG":          "AP_SOUTHEAST_1",
+		"Sydney, AU":             "AP_SOUTHEAST_2",
+		"Jakarta, ID":            "AP_SOUTHEAST_3",
+		"Tokyo, JP":              "AP_NORTHEAST_1",
+		"Seoul, KR":              "AP_NORTHEAST_2",
 		"Montreal, Québec, CA":   "CA_CENTRAL_1",
+		"Frankfurt, DE":          "EU_CENTRAL_1",
+		"Stockholm, SE":          "EU_NORTH_1",
+		"Milan, IT":              "EU_SOUTH_1",
+		"Dublin, IE":             "EU_WEST_1",
+		"London, England, UK":    "EU_WEST_2",
+		"Paris, FR":              "EU_WEST_3",
+		"Manama, BH":             "ME_SOUTH_1",
+		"São Paulo, BR":          "SA_EAST_1",
 		"Washington, DC, USA":    "US_EAST_1",
 		"Columbus, OH, USA":      "US_EAST_2",
 		"San Francisco, CA, USA": "US_WEST_1",
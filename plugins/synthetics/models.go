@@ -1,10 +1,17 @@
 package synthetics
 
+import "github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+
 type MonitorTag struct {
 	Key    string   `json:"key"`
 	Values []string `json:"values,omitempty"`
 }
 
+type monitorOption struct {
+	tag       string
+	attribute string
+}
+
 type MonitorEntity struct {
 	GUID         string `json:"guid"`
 	Name         string `json:"name"`
@@ -28,16 +35,14 @@ type MonitorLookupResponse struct {
 	} `json:"actor"`
 }
 
-type MonitorSearchResponse struct {
-	Actor struct {
-		EntitySearch struct {
-			Results struct {
-				Entities []MonitorEntity `json:"entities"`
-			} `json:"results"`
-		} `json:"entitySearch"`
-	} `json:"actor"`
+type PrivateLocationEntity struct {
+	GUID string       `json:"guid"`
+	Name string       `json:"name"`
+	Tags []MonitorTag `json:"tags"`
 }
 
+type MonitorSearchResponse = internal.EntitySearchResponse[MonitorEntity]
+
 type GetStepsResponse struct {
 	Actor struct {
 		Account struct {
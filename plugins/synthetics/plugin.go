@@ -4,10 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"net/url"
+	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/alecthomas/kong"
 	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
@@ -21,32 +20,34 @@ import (
 var Version string = "0.0.1"
 
 type SyntheticExporterCommand struct {
-	AccountID           int      `short:"i" required:"true" help:"The New Relic Account ID"`
-	APIKey              string   `short:"k" required:"true" help:"An API Key for the New Relic Acccount ID"`
-	MonitorID           []string `short:"m" required:"true" xor:"locator" help:"The individual synthetic monitor ID to export. May be specified multiple times."`
-	LocatorQuery        string   `short:"q" required:"true" xor:"locator" help:"The query used with NerdGraph to find monitors to export."`
-	ParallelWorkers     uint     `short:"w" required:"true" default:"10" hidden:"true" help:"Number of monitors to export in parallel. Defaults to 10"`
-	CreateAccountIdFile bool     `short:"a" hidden:"true" default:"true"`
-	importCommands      []plugin.ImportDirective
-	nrClient            *newrelic.NewRelic
-	outputDirectory     string
-	nrClientOptions     []newrelic.ConfigOption
-	logger              hclog.Logger
+	internal.CommonFlags `embed:""`
+
+	MonitorID         []string `short:"m" required:"true" xor:"locator" help:"The individual synthetic monitor ID to export. May be specified multiple times."`
+	LocatorQuery      string   `short:"q" required:"true" xor:"locator" help:"The query used with NerdGraph to find monitors to export."`
+	MaxPages          uint     `short:"p" default:"0" help:"The maximum number of NerdGraph result pages to search for monitors. 0 searches every page."`
+	importCommands    []plugin.ImportDirective
+	privateLocations  map[string]string
+	vsePasswords      map[string]string
+	secureCredentials map[string]string
+	resourceNames     *internal.ResourceNames
+	nrClient          *newrelic.NewRelic
+	outputDirectory   string
+	nrClientOptions   []newrelic.ConfigOption
+	logger            hclog.Logger
 }
 
 func NewSyntheticExporterCommand(options ...newrelic.ConfigOption) *SyntheticExporterCommand {
 	return &SyntheticExporterCommand{
-		importCommands:  []plugin.ImportDirective{},
-		nrClientOptions: options,
-		logger: hclog.New(&hclog.LoggerOptions{
-			Level:  hclog.Info,
-			Output: os.Stderr,
-		}),
+		importCommands:    []plugin.ImportDirective{},
+		privateLocations:  map[string]string{},
+		vsePasswords:      map[string]string{},
+		secureCredentials: map[string]string{},
+		resourceNames:     internal.NewResourceNames(),
+		nrClientOptions:   options,
+		logger:            internal.NewLogger(),
 	}
 }
 
-var errSkip = errors.New("don't export")
-
 func (s *SyntheticExporterCommand) Help() (string, error) {
 	return internal.PluginCommandHelp(s)
 }
@@ -79,139 +80,119 @@ func (s *SyntheticExporterCommand) Export(request plugin.ExportCommandRequest) (
 	}
 
 	ctx := context.Background()
-	queryVariables := map[string]any{"query": s.LocatorQuery}
-	s.nrClient, err = newrelic.New(append([]newrelic.ConfigOption{newrelic.ConfigPersonalAPIKey(s.APIKey)}, s.nrClientOptions...)...)
+	s.nrClient, err = s.NewClient(s.nrClientOptions...)
 	if err != nil {
 		return plugin.ExportResponse{}, err
 	}
 	s.outputDirectory = request.OutputDirectory
 
-	// This collects all synthetics from NerdGraph, and parses the response into a GetMonitorsResponse instance.
-	var response MonitorSearchResponse
-	if err := s.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getMonitors, queryVariables, &response); err != nil {
-		return plugin.ExportResponse{}, fmt.Errorf("error querying NerdGraph: %w", err)
+	// names are shared with every other export to the same directory, so resources keep their names between
+	// exports and other commands can refer to them
+	s.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
 	}
 
-	if !request.SkipProviderOutput {
-		// Create the provider declaration. Since this is static for our purposes, we can copy directly from
-		// a string constant
-		provider, err := os.Create(filepath.Join(request.OutputDirectory, fmt.Sprintf("newrelic_provider_%d.tf", s.AccountID)))
-		if err != nil {
-			return plugin.ExportResponse{}, fmt.Errorf("error creating provider file: %w", err)
-		}
+	// This collects all matching synthetics from NerdGraph, following the result cursor across pages
+	entities, err := s.searchMonitors(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := s.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
 
-		fmt.Fprintf(provider, providerTF, s.AccountID)
-		provider.Close()
+	// private locations are shared between monitors, so they're exported once, up front, and the
+	// monitors refer to them by address
+	if err := s.exportPrivateLocations(ctx, entities); err != nil {
+		return plugin.ExportResponse{}, err
 	}
 
-	if s.CreateAccountIdFile {
-		accountID, err := os.Create(filepath.Join(request.OutputDirectory, ".account_id"))
-		if err != nil {
-			return plugin.ExportResponse{}, fmt.Errorf("could not create .account_id: %w", err)
+	// the same goes for secure credentials, which monitors depend on rather than refer to
+	if err := s.exportSecureCredentials(entities); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// names are handed out first come, first served, so reserve them in the order NerdGraph returned the
+	// monitors rather than the order the workers get to them. That way, if two monitors' names collide, the
+	// same one is disambiguated every time
+	for _, monitor := range entities {
+		if resourceType, ok := monitorResourceTypes[monitor.MonitorType]; ok {
+			s.resourceNames.Reserve(resourceType, monitor.GUID, monitor.Name, "monitor")
 		}
-		defer accountID.Close()
-		fmt.Fprint(accountID, s.AccountID)
 	}
 
-	entities := make([]MonitorEntity, 0, len(response.Actor.EntitySearch.Results.Entities))
-	// if we're asking for individual entities, collect just them
-	if len(s.MonitorID) > 0 {
-		for _, e := range response.Actor.EntitySearch.Results.Entities {
+	// export the monitors themselves using a pool of s.ParallelWorkers (10 by default) goroutines. If any
+	// of them fail, commandError wraps an error the CLI understands, so it still processes all the
+	// successful exports
+	directives, commandError := internal.ExportInParallel(ctx, s.ParallelWorkers, entities, s.exportSingleMonitor)
+	directives = append(s.importCommands, directives...)
+
+	s.resourceNames.Record(directives...)
+	if err := s.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return s.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchMonitors runs the locator query against NerdGraph, following nextCursor until there are no pages left
+// or MaxPages pages have been read. If individual monitors were requested, only those monitors are collected,
+// and the search stops as soon as all of them have been found
+func (s *SyntheticExporterCommand) searchMonitors(ctx context.Context) ([]MonitorEntity, error) {
+	entities := []MonitorEntity{}
+	queryVariables := map[string]any{"query": s.LocatorQuery}
+	truncated, err := internal.SearchEntities(ctx, &s.nrClient.NerdGraph, getMonitors, queryVariables, s.MaxPages, func(page []MonitorEntity) bool {
+		if len(s.MonitorID) == 0 {
+			entities = append(entities, page...)
+			return true
+		}
+
+		// if we're asking for individual entities, collect just them
+		for _, e := range page {
 			if internal.IndexOf(e.GUID, s.MonitorID) >= 0 {
 				entities = append(entities, e)
-				if len(entities) == len(s.MonitorID) {
-					break
-				}
 			}
 		}
-	} else {
-		entities = append(entities, response.Actor.EntitySearch.Results.Entities...)
-	}
-
-	queueSize := len(entities)
-	errorCollector := make(chan error, queueSize)
-
-	// goroutines cannot return values. To collect errors that could happen in a goroutine,
-	// we create a channel that accepts delivers, then start a goroutine that watches that
-	// channel for errors created. When an error is collected, add it to a single error
-	// collecting all error messages, and at the end, return the error that was created if
-	// any errors were sent.
-	var commandError error = nil
-	go func() {
-		// ranging over a chan will return a value (in this case, an error) when one is
-		// available on the chan, and will end when the chan is closed.
-		for err := range errorCollector {
-			if commandError == nil {
-				commandError = errors.New("the following errors occurred exporting monitors")
-			}
-
-			commandError = fmt.Errorf("%s\n%s", commandError.Error(), err.Error())
-		}
-	}()
-
-	// WaitGroups are used to synchronize on many concurrent units of work. We add an initial
-	// size to the group, then each unit of work will call wg.Done(), which decrements the number
-	// of units remaining. wg.Wait() will block until all the wg.Done() calls occur.
-	wg := new(sync.WaitGroup)
-	wg.Add(queueSize)
-
-	// Create a chan that can accept all of our monitors at once, to ensure that it does not empty
-	// before everything is sent, however unlikely.
-	workQueue := make(chan MonitorEntity, queueSize)
 
-	// Create a pool of goroutines set to the size of s.ParallelWorkers (10 by default) that can collect
-	// work items. Each routine takes the context, the wait group (so it can call Done()), the queue of
-	// work items, and the error collector chan (so it can send errors if one occurs)
-	for i := 0; i < int(s.ParallelWorkers); i++ {
-		go s.exportMonitor(wg, ctx, workQueue, errorCollector)
+		return len(entities) < len(s.MonitorID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph: %w", err)
 	}
 
-	// Add all the monitors to the work queue, so the individual goroutines can pull off the monitors
-	// as they're able to do so
-	for _, monitor := range entities {
-		workQueue <- monitor
+	if truncated {
+		s.logger.Info("WARN:", hclog.Fmt("stopped searching for monitors after %d page(s), some monitors may not be exported", s.MaxPages))
 	}
 
-	wg.Wait()
-	// we have to close the work queue first or wg.Wait() blocks forever
-	close(workQueue)
+	return entities, nil
+}
 
-	// if commandError is not nil, wrap it in an error the CLI understands to process all the successful
-	// commands
-	if commandError != nil {
-		commandError = fmt.Errorf("%w: %v", plugin.ErrSomeExportsFailed, commandError)
+// publicLocation returns the name the provider uses for the public location a publicLocation tag value
+// refers to. Tags hold a location's label, but the name is accepted as well
+func publicLocation(val string) (string, bool) {
+	if name, ok := regionMap[val]; ok {
+		return name, true
 	}
 
-	// now that all of the work is done, we can close the error collector
-	close(errorCollector)
-	return plugin.ExportResponse{
-		Directives: s.importCommands,
-	}, commandError
-}
-
-// exportMonitor takes a monitor off a queue of monitors and exports it to file. it returns either an error, representing
-// the command line args that would be sent to "terraform import". this is always executed inside a goroutine
-func (s *SyntheticExporterCommand) exportMonitor(wg *sync.WaitGroup, ctx context.Context, work chan MonitorEntity, errors chan error) {
-	// grab a monitor off the work queue, and pass that monitor to exportSingleMonitor to do the work, because
-	// we can ensure that wg.Done is called via a defer, which protects against accidentally forgetting
-	// calling it in a code branch
-	for monitor := range work {
-		if err := s.exportSingleMonitor(wg, ctx, monitor); err != nil {
-			errors <- err
+	for _, name := range regionMap {
+		if name == val {
+			return name, true
 		}
 	}
+
+	return "", false
 }
 
 // exportSingleMonitor will choose the appropriate render method for the given monitor and call it, returning
-// an error if one occurred (or if the monitor's type is unsupported), or adding to the list of import commands
-func (s *SyntheticExporterCommand) exportSingleMonitor(wg *sync.WaitGroup, ctx context.Context, monitor MonitorEntity) error {
-	defer wg.Done()
-
-	s.logger.Info("Exporting Monitor %s", monitor.Name)
+// the import directive for the monitor, or an error if one occurred. Monitors with an unsupported type
+// return internal.ErrSkip. This is always executed inside a goroutine
+func (s *SyntheticExporterCommand) exportSingleMonitor(ctx context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
+	s.logger.Info("Exporting Monitor", "name", monitor.Name)
 
 	var render func(context.Context, MonitorEntity) (plugin.ImportDirective, error)
 
-	// at this time, SCRIPT_API monitors are not supported
 	switch monitor.MonitorType {
 	case "SIMPLE", "BROWSER":
 		render = s.renderSimpleMonitor
@@ -219,27 +200,33 @@ func (s *SyntheticExporterCommand) exportSingleMonitor(wg *sync.WaitGroup, ctx c
 		render = s.renderStepMonitor
 	case "SCRIPT_BROWSER":
 		render = s.renderScriptMonitor
+	case "SCRIPT_API":
+		render = s.renderScriptAPIMonitor
+	case "CERT_CHECK":
+		render = s.renderCertCheckMonitor
+	case "BROKEN_LINKS":
+		render = s.renderBrokenLinksMonitor
 	default:
 		s.logger.Info("WARN:", hclog.Fmt("unsupported monitor type %q", monitor.MonitorType))
-		return nil
+		return plugin.ImportDirective{}, internal.ErrSkip
 	}
 
 	importCmd, err := render(ctx, monitor)
 	if err != nil {
-		if errors.Is(err, errSkip) {
-			// ignore this monitor
-			return nil
+		if errors.Is(err, internal.ErrSkip) {
+			return plugin.ImportDirective{}, err
 		}
 
-		s.logger.Info("ERR:", hclog.Fmt("error rendering monitor %q: %v", monitor.Name, err))
-		return nil
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering monitor %q: %w", monitor.Name, err)
 	}
 
-	s.importCommands = append(s.importCommands, importCmd)
-	return nil
+	return importCmd, nil
 }
 
-func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceName string, monitor MonitorEntity) *hclwrite.File {
+// renderCommon creates a file containing the resource block for a monitor, with every attribute shared by all
+// monitor types set. It returns internal.ErrSkip if the monitor doesn't run in any location, and an error if
+// it runs in a location that can't be exported
+func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceName string, monitor MonitorEntity) (*hclwrite.File, error) {
 	file := hclwrite.NewEmptyFile()
 	block := file.Body().AppendNewBlock("resource", []string{resourceType, resourceName})
 
@@ -247,6 +234,8 @@ func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceNam
 	block.Body().SetAttributeValue("type", cty.StringVal(monitor.MonitorType))
 
 	locations := []cty.Value{}
+	privateLocations := []string{}
+	dependencies := []hclwrite.Tokens{}
 	period := "EVERY_MINUTE"
 	status := "ENABLED"
 	runtimeType := ""
@@ -255,7 +244,31 @@ func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceNam
 	for _, tag := range monitor.Tags {
 		if tag.Key == "publicLocation" {
 			for _, val := range tag.Values {
-				locations = append(locations, cty.StringVal(regionMap[val]))
+				location, ok := publicLocation(val)
+				if !ok {
+					return nil, fmt.Errorf("monitor %q runs in unknown public location %q", monitor.Name, val)
+				}
+
+				locations = append(locations, cty.StringVal(location))
+			}
+		}
+
+		if tag.Key == "privateLocation" {
+			for _, val := range tag.Values {
+				name, ok := s.privateLocations[val]
+				if !ok {
+					return nil, fmt.Errorf("monitor %q runs in unknown private location %q", monitor.Name, val)
+				}
+
+				privateLocations = append(privateLocations, name)
+			}
+		}
+
+		if tag.Key == "secureCredential" {
+			for _, val := range tag.Values {
+				if name, ok := s.secureCredentials[val]; ok {
+					dependencies = append(dependencies, internal.CreateReference(tfSecureCredentialType, name))
+				}
 			}
 		}
 
@@ -285,11 +298,25 @@ func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceNam
 		}
 	}
 
-	if len(locations) == 0 {
-		return nil
+	if len(locations) == 0 && len(privateLocations) == 0 {
+		return nil, internal.ErrSkip
+	}
+
+	if len(locations) > 0 {
+		block.Body().SetAttributeValue("locations_public", cty.ListVal(locations))
+	}
+
+	// script and step monitors take a block for each private location, since a location with verified script
+	// execution needs a password to run them. The other monitors take a list of locations
+	locationBlocks := resourceType == tfScriptMonitorType || resourceType == tfStepMonitorType
+	if len(privateLocations) > 0 && !locationBlocks {
+		references := []hclwrite.Tokens{}
+		for _, name := range privateLocations {
+			references = append(references, internal.CreateReference(tfPrivateLocationType, name, "id"))
+		}
+
+		block.Body().SetAttributeRaw("locations_private", hclwrite.TokensForTuple(references))
 	}
-	locationList := cty.ListVal(locations)
-	block.Body().SetAttributeValue("locations_public", locationList)
 
 	block.Body().AppendNewline()
 	block.Body().SetAttributeValue("period", cty.StringVal(period))
@@ -302,27 +329,61 @@ func (s *SyntheticExporterCommand) renderCommon(resourceType string, resourceNam
 	if runtimeTypeVersion != "" {
 		block.Body().SetAttributeValue("runtime_type_version", cty.StringVal(runtimeTypeVersion))
 	}
+	// the monitor's script can't run until the credentials it uses exist
+	if len(dependencies) > 0 {
+		block.Body().SetAttributeRaw("depends_on", hclwrite.TokensForTuple(dependencies))
+	}
 	block.Body().AppendNewline()
+	if locationBlocks {
+		for _, name := range privateLocations {
+			locationBlock := block.Body().AppendNewBlock("location_private", nil)
+			locationBlock.Body().SetAttributeRaw("guid", internal.CreateReference(tfPrivateLocationType, name, "id"))
+			if variableName, ok := s.vsePasswords[name]; ok {
+				locationBlock.Body().SetAttributeRaw("vse_password", internal.CreateReference("var", variableName))
+			}
+			block.Body().AppendNewline()
+		}
+	}
+
 	for _, b := range tagBlocks {
 		block.Body().AppendBlock(b)
 	}
 	block.Body().AppendNewline()
 
-	return file
+	return file, nil
 }
 
 func (s *SyntheticExporterCommand) renderSimpleMonitor(_ context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
 	tfResourceType := tfSimpleMonitorType
-	tfResourceName := internal.ToSnakeCase(monitor.Name)
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
 
-	file := s.renderCommon(tfResourceType, tfResourceName, monitor)
-	if file == nil {
-		return plugin.ImportDirective{}, errSkip
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
+	if err != nil {
+		return plugin.ImportDirective{}, err
 	}
 	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
-	resourceBlock.Body().SetAttributeValue("enable_screenshot_on_failure_and_script", cty.BoolVal(true))
-	resourceBlock.Body().SetAttributeValue("bypass_head_request", cty.BoolVal(true))
-	resourceBlock.Body().SetAttributeValue("verify_ssl", cty.BoolVal(true))
+
+	// NerdGraph has no query for a monitor's configuration; the options are only reported as tags, which is
+	// also where the provider reads them from when it refreshes the monitor. An option without a tag reads
+	// back as the provider's default, so it's left out rather than set, which plans without changes
+	options := simpleMonitorOptions
+	if monitor.MonitorType == "BROWSER" {
+		options = browserMonitorOptions
+	}
+
+	for _, option := range options {
+		val, ok := tagValue(monitor.Tags, option.tag)
+		if !ok {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(val)
+		if err != nil {
+			return plugin.ImportDirective{}, fmt.Errorf("monitor %q has an invalid %s tag %q: %w", monitor.Name, option.tag, val, err)
+		}
+
+		resourceBlock.Body().SetAttributeValue(option.attribute, cty.BoolVal(enabled))
+	}
 	resourceBlock.Body().SetAttributeValue("uri", cty.StringVal(monitor.MonitoredURL))
 
 	for _, tag := range monitor.Tags {
@@ -337,11 +398,11 @@ func (s *SyntheticExporterCommand) renderSimpleMonitor(_ context.Context, monito
 
 func (s *SyntheticExporterCommand) renderStepMonitor(ctx context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
 	tfResourceType := tfStepMonitorType
-	tfResourceName := internal.ToSnakeCase(monitor.Name)
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
 
-	file := s.renderCommon(tfResourceType, tfResourceName, monitor)
-	if file == nil {
-		return plugin.ImportDirective{}, errSkip
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
+	if err != nil {
+		return plugin.ImportDirective{}, err
 	}
 	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
 
@@ -363,17 +424,16 @@ func (s *SyntheticExporterCommand) renderStepMonitor(ctx context.Context, monito
 
 func (s *SyntheticExporterCommand) renderScriptMonitor(ctx context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
 	tfResourceType := tfScriptMonitorType
-	tfResourceName := internal.ToSnakeCase(monitor.Name)
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
 
-	file := s.renderCommon(tfResourceType, tfResourceName, monitor)
-	if file == nil {
-		return plugin.ImportDirective{}, errSkip
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
+	if err != nil {
+		return plugin.ImportDirective{}, err
 	}
 	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
 
-	vars := map[string]any{"accountID": s.AccountID, "guid": monitor.GUID}
-	var response GetScriptResponse
-	if err := s.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getScript, vars, &response); err != nil {
+	script, err := s.fetchScript(ctx, monitor)
+	if err != nil {
 		return plugin.ImportDirective{}, err
 	}
 
@@ -383,30 +443,127 @@ func (s *SyntheticExporterCommand) renderScriptMonitor(ctx context.Context, moni
 		}
 	}
 
-	resourceBlock.Body().SetAttributeRaw("script", internal.CreateHeredoc(response.Actor.Account.Synthetics.Script.Text, "-SCRIPT", true))
+	resourceBlock.Body().SetAttributeRaw("script", internal.CreateHeredoc(script, "-SCRIPT", true))
 
 	return s.printFile(file, monitor.GUID, tfResourceType, tfResourceName)
 }
 
-func (s *SyntheticExporterCommand) printFile(file *hclwrite.File, monitorGUID string, tfResourceType string, tfResourceName string) (plugin.ImportDirective, error) {
-	path, err := filepath.Abs(filepath.Join(s.outputDirectory, tfResourceName))
+func (s *SyntheticExporterCommand) renderScriptAPIMonitor(ctx context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
+	tfResourceType := tfScriptMonitorType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
+
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
 	if err != nil {
 		return plugin.ImportDirective{}, err
 	}
+	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
 
-	filePtr, err := os.Create(fmt.Sprintf("%s.tf", path))
+	script, err := s.fetchScript(ctx, monitor)
 	if err != nil {
 		return plugin.ImportDirective{}, err
 	}
-	defer filePtr.Close()
 
-	if _, err := file.WriteTo(filePtr); err != nil {
+	// monitors on the legacy runtime don't carry any runtime tags and must not declare a runtime. Monitors
+	// on the current runtime always run on node, so fill in whatever the tags left out
+	scriptLanguage := scriptLanguageJavascript
+	for _, tag := range monitor.Tags {
+		if tag.Key == "scriptLanguage" {
+			scriptLanguage = tag.Values[0]
+		}
+	}
+
+	if resourceBlock.Body().GetAttribute("runtime_type_version") != nil {
+		resourceBlock.Body().SetAttributeValue("runtime_type", cty.StringVal(runtimeTypeNodeAPI))
+		resourceBlock.Body().SetAttributeValue("script_language", cty.StringVal(scriptLanguage))
+	}
+
+	resourceBlock.Body().SetAttributeRaw("script", internal.CreateHeredoc(script, "-SCRIPT", true))
+
+	return s.printFile(file, monitor.GUID, tfResourceType, tfResourceName)
+}
+
+func (s *SyntheticExporterCommand) renderCertCheckMonitor(_ context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
+	tfResourceType := tfCertCheckMonitorType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
+
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
+	if err != nil {
 		return plugin.ImportDirective{}, err
 	}
+	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
 
-	return plugin.ImportDirective{
-		Resource: tfResourceType,
-		Name:     tfResourceName,
-		ID:       monitorGUID,
-	}, nil
+	// the monitor type is implied by the resource type, so cert check monitors don't accept one
+	resourceBlock.Body().RemoveAttribute("type")
+
+	expiration := ""
+	for _, tag := range monitor.Tags {
+		if tag.Key == "daysUntilExpiration" {
+			expiration = tag.Values[0]
+			break
+		}
+	}
+
+	days, err := strconv.ParseInt(expiration, 10, 64)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("monitor %q has an invalid certificate expiration %q: %w", monitor.Name, expiration, err)
+	}
+
+	resourceBlock.Body().SetAttributeValue("domain", cty.StringVal(monitorDomain(monitor.MonitoredURL)))
+	resourceBlock.Body().SetAttributeValue("certificate_expiration", cty.NumberIntVal(days))
+
+	return s.printFile(file, monitor.GUID, tfResourceType, tfResourceName)
+}
+
+func (s *SyntheticExporterCommand) renderBrokenLinksMonitor(_ context.Context, monitor MonitorEntity) (plugin.ImportDirective, error) {
+	tfResourceType := tfBrokenLinksMonitorType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, monitor.GUID, monitor.Name, "monitor")
+
+	file, err := s.renderCommon(tfResourceType, tfResourceName, monitor)
+	if err != nil {
+		return plugin.ImportDirective{}, err
+	}
+	resourceBlock := file.Body().FirstMatchingBlock("resource", []string{tfResourceType, tfResourceName})
+
+	// the monitor type is implied by the resource type, so broken links monitors don't accept one
+	resourceBlock.Body().RemoveAttribute("type")
+	resourceBlock.Body().SetAttributeValue("uri", cty.StringVal(monitor.MonitoredURL))
+
+	return s.printFile(file, monitor.GUID, tfResourceType, tfResourceName)
+}
+
+// tagValue returns the first value of the tag with the given key, and whether the tag was found
+func tagValue(tags []MonitorTag, key string) (string, bool) {
+	for _, tag := range tags {
+		if tag.Key == key && len(tag.Values) > 0 {
+			return tag.Values[0], true
+		}
+	}
+
+	return "", false
+}
+
+// monitorDomain returns the host a cert check monitor is checking. NerdGraph may report the monitored URL
+// with or without a scheme, so only use the parsed host if there is one
+func monitorDomain(monitoredURL string) string {
+	u, err := url.Parse(monitoredURL)
+	if err != nil || u.Host == "" {
+		return monitoredURL
+	}
+
+	return u.Hostname()
+}
+
+// fetchScript retrieves the source of a scripted monitor from NerdGraph
+func (s *SyntheticExporterCommand) fetchScript(ctx context.Context, monitor MonitorEntity) (string, error) {
+	vars := map[string]any{"accountID": s.AccountID, "guid": monitor.GUID}
+	var response GetScriptResponse
+	if err := s.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getScript, vars, &response); err != nil {
+		return "", err
+	}
+
+	return response.Actor.Account.Synthetics.Script.Text, nil
+}
+
+func (s *SyntheticExporterCommand) printFile(file *hclwrite.File, monitorGUID string, tfResourceType string, tfResourceName string) (plugin.ImportDirective, error) {
+	return internal.WriteResourceFile(s.outputDirectory, file, monitorGUID, tfResourceType, tfResourceName)
 }
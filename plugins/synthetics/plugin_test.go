@@ -15,7 +15,6 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
 	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/synthetics"
 	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
 )
@@ -27,13 +26,27 @@ var _ = Describe("Plugin", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(helpText).To(Equal(`
 Flags:
-  -i, --account-id=INT          The New Relic Account ID
-  -k, --api-key=STRING          An API Key for the New Relic Acccount ID
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
   -m, --monitor-id=MONITOR-ID,...
-                                The individual synthetic monitor ID to export.
-                                May be specified multiple times.
-  -q, --locator-query=STRING    The query used with NerdGraph to find monitors
-                                to export.
+                                   The individual synthetic monitor ID to
+                                   export. May be specified multiple times.
+  -q, --locator-query=STRING       The query used with NerdGraph to find
+                                   monitors to export.
+  -p, --max-pages=0                The maximum number of NerdGraph result pages
+                                   to search for monitors. 0 searches every
+                                   page.
 `))
 	})
 
@@ -91,15 +104,7 @@ Flags:
 				})
 				Expect(err).NotTo(HaveOccurred())
 
-				var responseJSON struct {
-					Data synthetics.MonitorSearchResponse `json:"data"`
-				}
-
-				b, err := os.ReadFile("testdata/get_monitors.json")
-				Expect(err).NotTo(HaveOccurred())
-
-				err = json.Unmarshal(b, &responseJSON)
-				Expect(err).NotTo(HaveOccurred())
+				entities := readMonitors("testdata/get_monitors.json", "testdata/get_monitors_page_2.json")
 
 				generatedFiles := 0
 				filepath.WalkDir(outputDirectory, func(path string, d fs.DirEntry, err error) error {
@@ -110,27 +115,62 @@ Flags:
 					return nil
 				})
 
-				// this checks for one terraform file for each monitor plus newrelic_provider.tf
-				Expect(generatedFiles).To(Equal(len(responseJSON.Data.Actor.EntitySearch.Results.Entities) + 1))
+				// this checks for one terraform file for each monitor plus newrelic_provider.tf, versions.tf, the
+				// two private locations the monitors run in, and the two secure credentials they use
+				Expect(generatedFiles).To(Equal(len(entities) + 6))
+				Expect(filepath.Join(outputDirectory, "versions.tf")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, ".account_id")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, "newrelic_provider_56789.tf")).To(BeAnExistingFile())
-				for _, entity := range responseJSON.Data.Actor.EntitySearch.Results.Entities {
-					scName := internal.ToSnakeCase(entity.Name)
-					Expect(filepath.Join(outputDirectory, scName+".tf")).To(BeAnExistingFile())
+				names := map[string]bool{}
+				for _, entity := range entities {
 					foundDirective := false
 					for _, directive := range resp.Directives {
 						if directive.ID == entity.GUID {
 							foundDirective = true
+							Expect(names).NotTo(HaveKey(directive.Name))
+							names[directive.Name] = true
+							Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
 							break
 						}
 					}
 
 					if !foundDirective {
-						Fail(fmt.Sprintf("Monitor GUID %s has an output file but doesn't have an associated import directive", entity.GUID))
+						Fail(fmt.Sprintf("Monitor GUID %s doesn't have an associated import directive", entity.GUID))
 					}
 				}
 			})
 
+			It("Gives every monitor a unique, valid resource name", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "4",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDRjMTI1YmI4LTdlNWEtNDNhOS1hZTRhLTQ0ZDM3NjVmNzQ2Mw",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDEzMDc3ZjFiLTQ5NTItNDg3My05YjAwLTJjOGQ4NDQ2OTdmYg",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGNlN2Q5ZWE0LWRiYjYtNDhlZS1hMDMyLWY1NmNiNmI4Y2IzNQ",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDc1ZGNjZDFiLWEzNmYtNGRkNi1iNzJjLTQ0MDg2N2QzOTE2NA",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				names := map[string]string{}
+				for _, directive := range resp.Directives {
+					names[directive.ID] = directive.Name
+					Expect(filepath.Join(outputDirectory, directive.Name+".tf")).To(BeAnExistingFile())
+				}
+
+				// "Login Check" comes first, so it keeps its name
+				Expect(names).To(Equal(map[string]string{
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfDRjMTI1YmI4LTdlNWEtNDNhOS1hZTRhLTQ0ZDM3NjVmNzQ2Mw": "login_check",
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfDEzMDc3ZjFiLTQ5NTItNDg3My05YjAwLTJjOGQ4NDQ2OTdmYg": "login_check_42ceb318",
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfGNlN2Q5ZWE0LWRiYjYtNDhlZS1hMDMyLWY1NmNiNmI4Y2IzNQ": "monitor_320589be",
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfDc1ZGNjZDFiLWEzNmYtNGRkNi1iNzJjLTQ0MDg2N2QzOTE2NA": "monitor_24_7_uptime",
+				}))
+			})
+
 			It("Generates specific monitors' files in outputDirectory", func() {
 				_, err := command.Export(plugin.ExportCommandRequest{
 					OutputDirectory:    outputDirectory,
@@ -142,6 +182,7 @@ Flags:
 						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
 						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGMxOWIyYWIzLWU0ZjktNDAxNC05NDgyLWZmNTkzYjZjM2RmOA",
 						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGY5ZjIwMzY5LTEwMzMtNDdmMy05ODBhLTY3ZGVkNTcxOWYxYQ",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDdkNjEzYjY0LWM1YmQtNDZkYi05ZDc4LTI2ZmY3NmEzOGM1Ng",
 						"-a",
 					},
 				})
@@ -155,12 +196,511 @@ Flags:
 
 					return nil
 				})
-				Expect(generatedFiles).To(Equal(5))
+				Expect(generatedFiles).To(Equal(9))
 				Expect(filepath.Join(outputDirectory, ".account_id")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, "newrelic_provider_56789.tf")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, "monitor_name_2.tf")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, "monitor_name_9.tf")).To(BeAnExistingFile())
 				Expect(filepath.Join(outputDirectory, "monitor_name_25.tf")).To(BeAnExistingFile())
+				Expect(filepath.Join(outputDirectory, "monitor_name_42.tf")).To(BeAnExistingFile())
+				Expect(filepath.Join(outputDirectory, "test_account_password.tf")).To(BeAnExistingFile())
+				Expect(filepath.Join(outputDirectory, "test_account_login_name.tf")).To(BeAnExistingFile())
+			})
+
+			It("Exports the secure credentials monitors use", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGY5ZjIwMzY5LTEwMzMtNDdmMy05ODBhLTY3ZGVkNTcxOWYxYQ",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(ContainElements(
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_secure_credential",
+						Name:     "test_account_password",
+						ID:       "TEST_ACCOUNT_PASSWORD",
+					},
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_secure_credential",
+						Name:     "test_account_login_name",
+						ID:       "TEST_ACCOUNT_LOGIN_NAME",
+					},
+				))
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "test_account_password.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(ContainSubstring(`variable "secure_credential_test_account_password" {`))
+				Expect(string(b)).To(MatchRegexp(`sensitive\s+= true`))
+				Expect(string(b)).To(MatchRegexp(`key\s+= "TEST_ACCOUNT_PASSWORD"`))
+				Expect(string(b)).To(MatchRegexp(`value\s+= var.secure_credential_test_account_password`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_25.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`depends_on\s+= \[newrelic_synthetics_secure_credential.test_account_password, newrelic_synthetics_secure_credential.test_account_login_name\]`))
+			})
+
+			It("Sets simple monitor options from their tags", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDNmOTk0YjI1LWIyNzAtNGE1Mi1hNzE3LTk2ZjUyYzJhZDg1Mw",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDdkNjEzYjY0LWM1YmQtNDZkYi05ZDc4LTI2ZmY3NmEzOGM1Ng",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "monitor_name_41.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`enable_screenshot_on_failure_and_script\s+= true`))
+				Expect(string(b)).To(MatchRegexp(`verify_ssl\s+= true`))
+				Expect(string(b)).NotTo(ContainSubstring("bypass_head_request"))
+				Expect(string(b)).NotTo(ContainSubstring("treat_redirect_as_failure"))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_42.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`bypass_head_request\s+= false`))
+				Expect(string(b)).To(MatchRegexp(`treat_redirect_as_failure\s+= true`))
+				Expect(string(b)).To(MatchRegexp(`verify_ssl\s+= true`))
+				Expect(string(b)).NotTo(ContainSubstring("enable_screenshot_on_failure_and_script"))
+			})
+
+			It("Leaves options without a tag to the provider's defaults", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGZlNTJmMDY2LTBhMTUtNDQyNC04NjNlLWE4NjEwOGI0ZjdkYw",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// Monitor Name 46 only has a useTlsValidation tag
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "monitor_name_46.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`verify_ssl\s+= true`))
+				Expect(string(b)).NotTo(ContainSubstring("bypass_head_request"))
+				Expect(string(b)).NotTo(ContainSubstring("treat_redirect_as_failure"))
+				Expect(string(b)).To(MatchRegexp(`validation_string\s+= "Accessibility"`))
+			})
+
+			It("Exports SCRIPT_API monitors as script monitors", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDJhYjQ0ZjZjLTNhNmUtNDYzNi05YmUyLWQxZjEwZDc4YzM0NQ",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(ConsistOf(plugin.ImportDirective{
+					Resource: "newrelic_synthetics_script_monitor",
+					Name:     "monitor_name_43",
+					ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfDJhYjQ0ZjZjLTNhNmUtNDYzNi05YmUyLWQxZjEwZDc4YzM0NQ",
+				}))
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "monitor_name_43.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`type\s+= "SCRIPT_API"`))
+				Expect(string(b)).To(MatchRegexp(`runtime_type\s+= "NODE_API"`))
+				Expect(string(b)).To(MatchRegexp(`runtime_type_version\s+= "16.10"`))
+				Expect(string(b)).To(MatchRegexp(`script_language\s+= "JAVASCRIPT"`))
+				Expect(string(b)).To(ContainSubstring("$http.get('https://api.example.com/status'"))
+			})
+
+			It("Exports CERT_CHECK and BROKEN_LINKS monitors", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDViMjFjOTk4LTYyZjUtNGE2ZS1iMmI1LWQ1ODBjNWJjNDM5Yw",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDkwYjJmNzE0LTNkMzgtNGJlNC04ZDU0LWE1M2Q3NmQ0ZjAxOQ",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(ConsistOf(
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_cert_check_monitor",
+						Name:     "monitor_name_44",
+						ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfDViMjFjOTk4LTYyZjUtNGE2ZS1iMmI1LWQ1ODBjNWJjNDM5Yw",
+					},
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_broken_links_monitor",
+						Name:     "monitor_name_45",
+						ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfDkwYjJmNzE0LTNkMzgtNGJlNC04ZDU0LWE1M2Q3NmQ0ZjAxOQ",
+					},
+				))
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "monitor_name_44.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).NotTo(MatchRegexp(`\btype\s+=`))
+				Expect(string(b)).To(MatchRegexp(`domain\s+= "www.example.com"`))
+				Expect(string(b)).To(MatchRegexp(`certificate_expiration\s+= 14`))
+				Expect(string(b)).To(MatchRegexp(`period\s+= "EVERY_DAY"`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_45.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).NotTo(MatchRegexp(`\btype\s+=`))
+				Expect(string(b)).To(MatchRegexp(`uri\s+= "https://www.example.com/docs"`))
+			})
+
+			It("Exports the private locations monitors run in", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGZlNTJmMDY2LTBhMTUtNDQyNC04NjNlLWE4NjEwOGI0ZjdkYw",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDQ2ZTk5NjU4LWJiYTgtNDdlMi1iOGY0LTM5YzRhYmM1OTkyMg",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(ContainElements(
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_private_location",
+						Name:     "private_location_1",
+						ID:       "MTc4ODMzMHxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfGRmMmE5ZTg2LTQ1ZjktNDk1Mi1hMWYxLTEwMmIxOTZlNjQzMg",
+					},
+					plugin.ImportDirective{
+						Resource: "newrelic_synthetics_private_location",
+						Name:     "private_location_2",
+						ID:       "MTc4ODMzMHxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfDhjNTM3ZTBmLTVmNGMtNGY3MS1hMjZlLWE1ZjIwOTk1MWI2MQ",
+					},
+				))
+				Expect(resp.Directives).To(HaveLen(4))
+				Expect(filepath.Join(outputDirectory, "unused_private_location.tf")).NotTo(BeAnExistingFile())
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "private_location_2.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`description\s+= "DMZ minion"`))
+				Expect(string(b)).To(MatchRegexp(`verified_script_execution\s+= true`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_46.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).NotTo(ContainSubstring("locations_public"))
+				Expect(string(b)).To(MatchRegexp(`locations_private\s+= \[newrelic_synthetics_private_location.private_location_1.id\]`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_47.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(ContainSubstring("locations_public"))
+				Expect(string(b)).To(MatchRegexp(`locations_private\s+= \[newrelic_synthetics_private_location.private_location_2.id\]`))
+			})
+
+			It("Gives script and step monitors a block for each private location", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDVkM2MyYjFhLTQ5YTktNGM0OS04ZDQ5LTQ5NDlhOWI5YzlkOQ",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDZlNGQzYzJiLTUwYjAtNGQ1MC05ZTUwLTUwNTBiMGMwZDBlMA",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// the password verified script execution needs is declared once, with the location
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "private_location_2.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(`variable "private_location_private_location_2_vse_password" {
+  type        = string
+  description = "The verified script execution password of the Private Location 2 private location"
+  sensitive   = true
+}
+
+resource "newrelic_synthetics_private_location" "private_location_2" {
+  name                      = "Private Location 2"
+  description               = "DMZ minion"
+  verified_script_execution = true
+}
+`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_49.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).NotTo(ContainSubstring("locations_private"))
+				Expect(string(b)).To(ContainSubstring(`
+  location_private {
+    guid         = newrelic_synthetics_private_location.private_location_2.id
+    vse_password = var.private_location_private_location_2_vse_password
+  }
+`))
+
+				b, err = os.ReadFile(filepath.Join(outputDirectory, "monitor_name_50.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(HavePrefix(`resource "newrelic_synthetics_step_monitor" "monitor_name_50" {`))
+				Expect(string(b)).NotTo(ContainSubstring("locations_private"))
+				Expect(string(b)).To(MatchRegexp(`locations_public\s+= \["US_WEST_2"\]`))
+				Expect(string(b)).To(ContainSubstring(`
+  location_private {
+    guid = newrelic_synthetics_private_location.private_location_1.id
+  }
+`))
+			})
+
+			It("Fails to export monitors running in unknown private locations", func() {
+				privateLocationsFixture = "testdata/get_no_private_locations.json"
+				DeferCleanup(func() {
+					privateLocationsFixture = "testdata/get_private_locations.json"
+				})
+
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGZlNTJmMDY2LTBhMTUtNDQyNC04NjNlLWE4NjEwOGI0ZjdkYw",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDZlNGQzYzJiLTUwYjAtNGQ1MC05ZTUwLTUwNTBiMGMwZDBlMA",
+					},
+				})
+				Expect(err).To(MatchError(plugin.ErrSomeExportsFailed))
+				Expect(err.Error()).To(ContainSubstring(`monitor "Monitor Name 46" runs in unknown private location "Private Location 1"`))
+				Expect(err.Error()).To(ContainSubstring(`monitor "Monitor Name 50" runs in unknown private location "MTc4ODMzMHxTWU5USHxQUklWQVRFX0xPQ0FUSU9OfGRmMmE5ZTg2LTQ1ZjktNDk1Mi1hMWYxLTEwMmIxOTZlNjQzMg"`))
+				Expect(resp.Directives).To(BeEmpty())
+			})
+
+			It("Maps public locations outside North America", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDBhZWUzYTRmLTk4OGEtNDkyYy1iZjE0LTIxNWEyZTJhNjJkZQ",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "monitor_name_48.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(MatchRegexp(`locations_public\s+= \["AP_NORTHEAST_1", "AP_SOUTHEAST_3", "EU_WEST_1"\]`))
+			})
+
+			It("Fails to export monitors running in unknown public locations", func() {
+				monitorsFixture = "testdata/get_monitors_unknown_public_location.json"
+				DeferCleanup(func() {
+					monitorsFixture = "testdata/get_monitors.json"
+				})
+
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-q", "domain = 'SYNTH'",
+					},
+				})
+				Expect(err).To(MatchError(plugin.ErrSomeExportsFailed))
+				Expect(err.Error()).To(ContainSubstring(`monitor "Monitor Name 51" runs in unknown public location "Atlantis, AQ"`))
+
+				// the monitor in known locations still exports
+				Expect(resp.Directives).To(ConsistOf(plugin.ImportDirective{
+					Resource: "newrelic_synthetics_monitor",
+					Name:     "monitor_name_48",
+					ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfDBhZWUzYTRmLTk4OGEtNDkyYy1iZjE0LTIxNWEyZTJhNjJkZQ",
+				}))
+				Expect(filepath.Join(outputDirectory, "monitor_name_51.tf")).NotTo(BeAnExistingFile())
+			})
+
+			It("Writes import blocks instead of directives", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+						"--import-mode", "blocks",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(BeEmpty())
+
+				b, err := os.ReadFile(filepath.Join(outputDirectory, "imports.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(b)).To(Equal(`import {
+  to = newrelic_synthetics_monitor.monitor_name_2
+  id = "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ"
+}
+`))
+			})
+
+			It("Writes import blocks alongside directives", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+						"--import-mode", "both",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(HaveLen(1))
+				Expect(filepath.Join(outputDirectory, "imports.tf")).To(BeAnExistingFile())
+			})
+
+			It("Exports many monitors concurrently", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "32",
+						"-q", "domain = 'SYNTH'",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// every monitor, plus the two private locations and two secure credentials they use
+				entities := readMonitors("testdata/get_monitors.json", "testdata/get_monitors_page_2.json")
+				Expect(resp.Directives).To(HaveLen(len(entities) + 4))
+			})
+
+			It("Reports monitors that fail to export", func() {
+				failingServer := mockNerdGraphServer(
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfGMxOWIyYWIzLWU0ZjktNDAxNC05NDgyLWZmNTkzYjZjM2RmOA",
+					"MTc4ODMzMHxTWU5USHxNT05JVE9SfGY5ZjIwMzY5LTEwMzMtNDdmMy05ODBhLTY3ZGVkNTcxOWYxYQ",
+				)
+				defer failingServer.Close()
+
+				command = synthetics.NewSyntheticExporterCommand(
+					newrelic.ConfigBaseURL(failingServer.URL),
+					newrelic.ConfigNerdGraphBaseURL(failingServer.URL),
+				)
+
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "8",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGMxOWIyYWIzLWU0ZjktNDAxNC05NDgyLWZmNTkzYjZjM2RmOA",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfGY5ZjIwMzY5LTEwMzMtNDdmMy05ODBhLTY3ZGVkNTcxOWYxYQ",
+					},
+				})
+				Expect(err).To(MatchError(plugin.ErrSomeExportsFailed))
+				Expect(err.Error()).To(ContainSubstring(`error rendering monitor "Monitor Name 9"`))
+				Expect(err.Error()).To(ContainSubstring(`error rendering monitor "Monitor Name 25"`))
+
+				// the monitor that did export, and the secure credentials Monitor Name 25 uses
+				Expect(resp.Directives).To(HaveLen(3))
+				Expect(resp.Directives).To(ContainElement(plugin.ImportDirective{
+					Resource: "newrelic_synthetics_monitor",
+					Name:     "monitor_name_2",
+					ID:       "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+				}))
+			})
+
+			DescribeTable("Configures the provider for the account's region", func(args []string, region string) {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: false,
+					PluginArgs: append([]string{
+						"-i", "56789",
+						"-k", "1234",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+					}, args...),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Directives).To(HaveLen(1))
+
+				provider, err := os.ReadFile(filepath.Join(outputDirectory, "newrelic_provider_56789.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(provider)).To(MatchRegexp(`region\s+= "%s"`, region))
+				Expect(string(provider)).NotTo(ContainSubstring("sensitive"))
+			},
+				Entry("defaults to US", []string{}, "US"),
+				Entry("US", []string{"-r", "US"}, "US"),
+				Entry("EU", []string{"--region", "EU"}, "EU"),
+			)
+
+			It("Pins the provider version and marks api_key as sensitive when asked", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: false,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-m", "MTc4ODMzMHxTWU5USHxNT05JVE9SfDg0YmNkNWZhLWVhMzAtNDc5Yy04YmY0LTY3NzU2NTc1ZmQ1ZQ",
+						"--provider-version", "~> 3.0",
+						"--sensitive-api-key",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := os.ReadFile(filepath.Join(outputDirectory, "versions.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(versions)).To(MatchRegexp(`source\s+= "newrelic/newrelic"`))
+				Expect(string(versions)).To(MatchRegexp(`version\s+= "~> 3.0"`))
+
+				provider, err := os.ReadFile(filepath.Join(outputDirectory, "newrelic_provider_56789.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(provider)).To(MatchRegexp(`sensitive\s+= true`))
+			})
+
+			It("Rejects unknown regions", func() {
+				_, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory: outputDirectory,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-r", "APAC",
+						"-q", "domain = 'SYNTH'",
+					},
+				})
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("Stops searching after the maximum number of pages", func() {
+				resp, err := command.Export(plugin.ExportCommandRequest{
+					OutputDirectory:    outputDirectory,
+					SkipProviderOutput: true,
+					PluginArgs: []string{
+						"-i", "56789",
+						"-k", "1234",
+						"-w", "1",
+						"-q", "domain = 'SYNTH'",
+						"-p", "1",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// the first page also uses two secure credentials
+				entities := readMonitors("testdata/get_monitors.json")
+				Expect(resp.Directives).To(HaveLen(len(entities) + 2))
+				Expect(filepath.Join(outputDirectory, "monitor_name_40.tf")).To(BeAnExistingFile())
+				Expect(filepath.Join(outputDirectory, "monitor_name_41.tf")).NotTo(BeAnExistingFile())
 			})
 		})
 	})
@@ -169,11 +709,42 @@ Flags:
 type nerdgraphQuery struct {
 	Query     string `json:"query"`
 	Variables struct {
-		GUID string `json:"guid"`
+		GUID   string `json:"guid"`
+		Query  string `json:"query"`
+		Cursor string `json:"cursor"`
 	} `json:"variables"`
 }
 
-func mockNerdGraphServer() *httptest.Server {
+// readMonitors reads every monitor out of the given entitySearch fixtures
+func readMonitors(files ...string) []synthetics.MonitorEntity {
+	entities := []synthetics.MonitorEntity{}
+	for _, file := range files {
+		var responseJSON struct {
+			Data synthetics.MonitorSearchResponse `json:"data"`
+		}
+
+		b, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = json.Unmarshal(b, &responseJSON)
+		Expect(err).NotTo(HaveOccurred())
+
+		entities = append(entities, responseJSON.Data.Actor.EntitySearch.Results.Entities...)
+	}
+
+	return entities
+}
+
+// monitorsFixture is the file mockNerdGraphServer answers the first page of monitor searches with
+var monitorsFixture = "testdata/get_monitors.json"
+
+// privateLocationsFixture is the file mockNerdGraphServer answers private location searches with
+var privateLocationsFixture = "testdata/get_private_locations.json"
+
+// mockNerdGraphServer serves NerdGraph responses from testdata. Any queries for the monitors in
+// failingGUIDs return an error
+
+func mockNerdGraphServer(failingGUIDs ...string) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Body == nil {
 			return
@@ -188,8 +759,24 @@ func mockNerdGraphServer() *httptest.Server {
 			return
 		}
 
+		for _, guid := range failingGUIDs {
+			if request.Variables.GUID == guid {
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"errors": [{"message": "could not load monitor %s"}]}`, guid)
+				return
+			}
+		}
+
 		if strings.Contains(request.Query, "entitySearch") {
-			data, err := os.ReadFile("testdata/get_monitors.json")
+			// the first page has no cursor, later pages are stored as get_monitors_<cursor>.json
+			file := monitorsFixture
+			if strings.Contains(request.Variables.Query, "PRIVATE_LOCATION") {
+				file = privateLocationsFixture
+			} else if request.Variables.Cursor != "" {
+				file = fmt.Sprintf("testdata/get_monitors_%s.json", request.Variables.Cursor)
+			}
+
+			data, err := os.ReadFile(file)
 			if os.IsNotExist(err) {
 				w.WriteHeader(http.StatusNotFound)
 				fmt.Fprintln(w, err.Error())
@@ -0,0 +1,110 @@
+package synthetics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportPrivateLocations writes a newrelic_synthetics_private_location resource for every private location
+// the given monitors run in, and records the resource name of each so renderCommon can refer to the
+// locations by address. The privateLocation tag may hold either a location's name or its GUID, so both
+// are recorded. Locations that aren't found aren't recorded, so the monitors running in them fail to
+// export. This must finish before any monitors are rendered.
+func (s *SyntheticExporterCommand) exportPrivateLocations(ctx context.Context, monitors []MonitorEntity) error {
+	wanted := []string{}
+	for _, monitor := range monitors {
+		for _, tag := range monitor.Tags {
+			if tag.Key != "privateLocation" {
+				continue
+			}
+
+			for _, val := range tag.Values {
+				if internal.IndexOf(val, wanted) < 0 {
+					wanted = append(wanted, val)
+				}
+			}
+		}
+	}
+
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	locations := []PrivateLocationEntity{}
+	queryVariables := map[string]any{"query": privateLocationQuery}
+	_, err := internal.SearchEntities(ctx, &s.nrClient.NerdGraph, getPrivateLocations, queryVariables, 0, func(page []PrivateLocationEntity) bool {
+		locations = append(locations, page...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error querying NerdGraph for private locations: %w", err)
+	}
+
+	for _, location := range locations {
+		if internal.IndexOf(location.Name, wanted) < 0 && internal.IndexOf(location.GUID, wanted) < 0 {
+			continue
+		}
+
+		s.logger.Info("Exporting Private Location", "name", location.Name)
+		importCmd, err := s.renderPrivateLocation(location)
+		if err != nil {
+			return fmt.Errorf("error rendering private location %q: %w", location.Name, err)
+		}
+
+		s.privateLocations[location.Name] = importCmd.Name
+		s.privateLocations[location.GUID] = importCmd.Name
+		s.importCommands = append(s.importCommands, importCmd)
+	}
+
+	return nil
+}
+
+// renderPrivateLocation writes a newrelic_synthetics_private_location resource. Script and step monitors
+// running in a location with verified script execution need its password, which New Relic never returns, so
+// it's read from a sensitive variable declared alongside the location
+func (s *SyntheticExporterCommand) renderPrivateLocation(location PrivateLocationEntity) (plugin.ImportDirective, error) {
+	tfResourceType := tfPrivateLocationType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, location.GUID, location.Name, "private_location")
+
+	file := hclwrite.NewEmptyFile()
+
+	description := ""
+	verifiedScriptExecution := false
+	for _, tag := range location.Tags {
+		if len(tag.Values) == 0 {
+			continue
+		}
+
+		if tag.Key == "description" {
+			description = tag.Values[0]
+		}
+
+		if tag.Key == "verifiedScriptExecution" {
+			verifiedScriptExecution, _ = strconv.ParseBool(tag.Values[0])
+		}
+	}
+
+	if verifiedScriptExecution {
+		variableName := fmt.Sprintf("private_location_%s_vse_password", tfResourceName)
+		variable := file.Body().AppendNewBlock("variable", []string{variableName})
+		variable.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+		variable.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The verified script execution password of the %s private location", location.Name)))
+		variable.Body().SetAttributeValue("sensitive", cty.BoolVal(true))
+		file.Body().AppendNewline()
+
+		s.vsePasswords[tfResourceName] = variableName
+	}
+
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeValue("name", cty.StringVal(location.Name))
+	block.Body().SetAttributeValue("description", cty.StringVal(description))
+	block.Body().SetAttributeValue("verified_script_execution", cty.BoolVal(verifiedScriptExecution))
+
+	return s.printFile(file, location.GUID, tfResourceType, tfResourceName)
+}
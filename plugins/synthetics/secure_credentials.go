@@ -0,0 +1,64 @@
+package synthetics
+
+import (
+	"fmt"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportSecureCredentials writes a newrelic_synthetics_secure_credential resource for every secure credential
+// the given monitors use, and records the resource name of each so renderCommon can make the monitors depend
+// on them. New Relic never returns a credential's value, so each value is read from a sensitive variable that
+// must be supplied when applying. This must finish before any monitors are rendered.
+func (s *SyntheticExporterCommand) exportSecureCredentials(monitors []MonitorEntity) error {
+	keys := []string{}
+	for _, monitor := range monitors {
+		for _, tag := range monitor.Tags {
+			if tag.Key != "secureCredential" {
+				continue
+			}
+
+			for _, val := range tag.Values {
+				if internal.IndexOf(val, keys) < 0 {
+					keys = append(keys, val)
+				}
+			}
+		}
+	}
+
+	for _, key := range keys {
+		s.logger.Info("Exporting Secure Credential", "key", key)
+		importCmd, err := s.renderSecureCredential(key)
+		if err != nil {
+			return fmt.Errorf("error rendering secure credential %q: %w", key, err)
+		}
+
+		s.secureCredentials[key] = importCmd.Name
+		s.importCommands = append(s.importCommands, importCmd)
+	}
+
+	return nil
+}
+
+func (s *SyntheticExporterCommand) renderSecureCredential(key string) (plugin.ImportDirective, error) {
+	tfResourceType := tfSecureCredentialType
+	tfResourceName := s.resourceNames.Reserve(tfResourceType, key, key, "secure_credential")
+	variableName := fmt.Sprintf("secure_credential_%s", tfResourceName)
+
+	file := hclwrite.NewEmptyFile()
+	variable := file.Body().AppendNewBlock("variable", []string{variableName})
+	variable.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+	variable.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The value of the %s secure credential", key)))
+	variable.Body().SetAttributeValue("sensitive", cty.BoolVal(true))
+	file.Body().AppendNewline()
+
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeValue("key", cty.StringVal(key))
+	block.Body().SetAttributeRaw("value", internal.CreateReference("var", variableName))
+
+	// secure credentials are imported by key rather than by GUID
+	return s.printFile(file, key, tfResourceType, tfResourceName)
+}
@@ -0,0 +1,39 @@
+package tags
+
+const (
+	tfEntityTagsType = "newrelic_entity_tags"
+
+	domainSynthetics = "SYNTH"
+
+	// by default, the tags of the entities in the account that other commands don't already export tags for
+	// are exported
+	defaultLocatorQuery = "accountId = %d AND domain IN ('APM', 'BROWSER', 'INFRA')"
+
+	getEntityTags = `query($query: String!, $cursor: String) {
+	actor {
+		entitySearch(query: $query) {
+			results(cursor: $cursor) {
+				nextCursor
+				entities {
+					guid
+					name
+					domain
+					goldenTags {
+						tags {
+							key
+						}
+					}
+					tagsWithMetadata {
+						key
+						values {
+							mutable
+							value
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
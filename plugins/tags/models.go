@@ -0,0 +1,29 @@
+package tags
+
+import (
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+)
+
+type EntityTag struct {
+	Key    string           `json:"key"`
+	Values []EntityTagValue `json:"values,omitempty"`
+}
+
+// EntityTagValue is a single value of a tag. Values New Relic sets itself, such as an entity's account, can't
+// be changed
+type EntityTagValue struct {
+	Mutable bool   `json:"mutable"`
+	Value   string `json:"value"`
+}
+
+type TaggedEntity struct {
+	GUID       string `json:"guid"`
+	Name       string `json:"name"`
+	Domain     string `json:"domain"`
+	GoldenTags struct {
+		Tags []EntityTag `json:"tags"`
+	} `json:"goldenTags"`
+	Tags []EntityTag `json:"tagsWithMetadata"`
+}
+
+type TaggedEntitySearchResponse = internal.EntitySearchResponse[TaggedEntity]
@@ -0,0 +1,174 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type EntityTagExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	LocatorQuery    string `short:"q" help:"The query used with NerdGraph to find the entities whose tags are exported. By default, the tags of every APM, browser and infrastructure entity in the account are exported."`
+	MaxPages        uint   `short:"p" default:"0" help:"The maximum number of NerdGraph result pages to search for entities. 0 searches every page."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewEntityTagExporterCommand(options ...newrelic.ConfigOption) *EntityTagExporterCommand {
+	return &EntityTagExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (t *EntityTagExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(t)
+}
+
+func (t *EntityTagExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-entity-tags",
+		Description: "Export the tags of New Relic Entities from the specified New Relic Account. Synthetic monitors are left out, since their tags are exported with them. Entities exported to the same directory are referred to by address",
+		Summary:     "Export New Relic Entity Tags from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (t *EntityTagExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(t)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if t.LocatorQuery == "" {
+		t.LocatorQuery = fmt.Sprintf(defaultLocatorQuery, t.AccountID)
+	}
+
+	ctx := context.Background()
+	t.nrClient, err = t.NewClient(t.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	t.outputDirectory = request.OutputDirectory
+
+	t.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	entities, err := t.searchEntities(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := t.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, entity := range entities {
+		t.resourceNames.Reserve(tfEntityTagsType, entity.GUID, entity.Name+"_tags", "entity")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, t.ParallelWorkers, entities, t.exportTags)
+
+	t.resourceNames.Record(directives...)
+	if err := t.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return t.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// searchEntities runs the locator query against NerdGraph, following nextCursor until there are no pages left
+// or MaxPages pages have been read
+func (t *EntityTagExporterCommand) searchEntities(ctx context.Context) ([]TaggedEntity, error) {
+	entities := []TaggedEntity{}
+	queryVariables := map[string]any{"query": t.LocatorQuery}
+	truncated, err := internal.SearchEntities(ctx, &t.nrClient.NerdGraph, getEntityTags, queryVariables, t.MaxPages, func(page []TaggedEntity) bool {
+		entities = append(entities, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for entities: %w", err)
+	}
+
+	if truncated {
+		t.logger.Info("WARN:", hclog.Fmt("stopped searching for entities after %d page(s), the tags of some entities may not be exported", t.MaxPages))
+	}
+
+	return entities, nil
+}
+
+func (t *EntityTagExporterCommand) exportTags(_ context.Context, entity TaggedEntity) (plugin.ImportDirective, error) {
+	if entity.Domain == domainSynthetics {
+		t.logger.Info("WARN:", hclog.Fmt("%q is a synthetic monitor, whose tags are exported with the monitor. Skipping", entity.Name))
+		return plugin.ImportDirective{}, internal.ErrSkip
+	}
+
+	// golden tags and the values New Relic sets itself can't be managed, the same as in synthetic monitors
+	tagBlocks := []*hclwrite.Block{}
+	for _, tag := range entity.Tags {
+		if internal.IndexOfWithField(tag, entity.GoldenTags.Tags, "Key") >= 0 {
+			continue
+		}
+
+		values := []string{}
+		for _, value := range tag.Values {
+			if value.Mutable {
+				values = append(values, value.Value)
+			}
+		}
+
+		if len(values) > 0 {
+			tagBlock := hclwrite.NewBlock("tag", nil)
+			tagBlock.Body().SetAttributeValue("key", cty.StringVal(tag.Key))
+			tagBlock.Body().SetAttributeValue("values", internal.ToCtyList(values))
+			tagBlocks = append(tagBlocks, tagBlock)
+		}
+	}
+
+	if len(tagBlocks) == 0 {
+		return plugin.ImportDirective{}, internal.ErrSkip
+	}
+
+	t.logger.Info("Exporting Entity Tags", "name", entity.Name)
+
+	importID := entity.GUID
+	tfResourceType := tfEntityTagsType
+	tfResourceName := t.resourceNames.Reserve(tfResourceType, importID, entity.Name+"_tags", "entity")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	block.Body().SetAttributeRaw("guid", internal.CreateEntityReference(t.resourceNames, entity.GUID))
+	block.Body().AppendNewline()
+	for _, tagBlock := range tagBlocks {
+		block.Body().AppendBlock(tagBlock)
+	}
+
+	directive, err := internal.WriteResourceFile(t.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering the tags of %q: %w", entity.Name, err)
+	}
+
+	return directive, nil
+}
@@ -0,0 +1,205 @@
+package tags_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/tags"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+const (
+	applicationGUID = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+	hostGUID        = "NTY3ODl8SU5GUkF8SE9TVHw3MDAx"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		t := &tags.EntityTagExporterCommand{}
+		helpText, err := t.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -q, --locator-query=STRING       The query used with NerdGraph to find
+                                   the entities whose tags are exported.
+                                   By default, the tags of every APM, browser
+                                   and infrastructure entity in the account are
+                                   exported.
+  -p, --max-pages=0                The maximum number of NerdGraph result pages
+                                   to search for entities. 0 searches every
+                                   page.
+`))
+	})
+
+	It("Generates an info", func() {
+		t := &tags.EntityTagExporterCommand{}
+		info, err := t.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-entity-tags"))
+		Expect(info.Version).To(Equal(plugin.FromString(tags.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *tags.EntityTagExporterCommand
+			outputDirectory string
+			searchQuery     string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer(&searchQuery)
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = tags.NewEntityTagExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports the tags that can be managed, skipping synthetic monitors", func() {
+			resp := export()
+			Expect(searchQuery).To(Equal("accountId = 56789 AND domain IN ('APM', 'BROWSER', 'INFRA')"))
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_entity_tags", Name: "checkout_service_tags", ID: applicationGUID},
+				plugin.ImportDirective{Resource: "newrelic_entity_tags", Name: "checkout_host_1_tags", ID: hostGUID},
+			))
+
+			Expect(readFile("checkout_service_tags.tf")).To(Equal(`resource "newrelic_entity_tags" "checkout_service_tags" {
+  guid = "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"
+
+  tag {
+    key    = "team"
+    values = ["checkout"]
+  }
+  tag {
+    key    = "env"
+    values = ["prod", "us"]
+  }
+}
+`))
+			Expect(readFile("checkout_host_1_tags.tf")).To(Equal(`resource "newrelic_entity_tags" "checkout_host_1_tags" {
+  guid = "NTY3ODl8SU5GUkF8SE9TVHw3MDAx"
+
+  tag {
+    key    = "role"
+    values = ["web"]
+  }
+}
+`))
+		})
+
+		It("Uses the locator query", func() {
+			export("-q", "name = 'Checkout Service'")
+			Expect(searchQuery).To(Equal("name = 'Checkout Service'"))
+		})
+
+		It("Refers to entities exported to the same directory", func() {
+			names := fmt.Sprintf(`{"names":{"newrelic_application_settings":{"%[1]s":"checkout_service"}},"exported":{"newrelic_application_settings":["%[1]s"]}}`, applicationGUID)
+			Expect(os.WriteFile(filepath.Join(outputDirectory, ".resource_names.json"), []byte(names), 0644)).To(Succeed())
+
+			export()
+			Expect(readFile("checkout_service_tags.tf")).To(ContainSubstring("guid = newrelic_application_settings.checkout_service.id\n"))
+
+			// the tags are recorded under their own resource type, so other commands still refer to the entity
+			saved, err := internal.LoadResourceNames(outputDirectory)
+			Expect(err).NotTo(HaveOccurred())
+			resourceType, name, ok := saved.LookupResource(applicationGUID, internal.EntityResourceTypes...)
+			Expect(ok).To(BeTrue())
+			Expect(resourceType).To(Equal("newrelic_application_settings"))
+			Expect(name).To(Equal("checkout_service"))
+
+			_, ok = saved.Lookup("newrelic_entity_tags", applicationGUID)
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		Query string `json:"query"`
+	} `json:"variables"`
+}
+
+func mockNerdGraphServer(searchQuery *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !strings.Contains(request.Query, "tagsWithMetadata") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		*searchQuery = request.Variables.Query
+		data, err := os.ReadFile("testdata/get_entity_tags.json")
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
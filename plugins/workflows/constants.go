@@ -0,0 +1,121 @@
+package workflows
+
+const (
+	tfDestinationType = "newrelic_notification_destination"
+	tfChannelType     = "newrelic_notification_channel"
+	tfWorkflowType    = "newrelic_workflow"
+
+	authTypeBasic = "BASIC"
+	authTypeToken = "TOKEN"
+
+	enrichmentTypeNrql = "NRQL"
+
+	getDestinations = `query($accountID: Int!, $cursor: String) {
+	actor {
+		account(id: $accountID) {
+			aiNotifications {
+				destinations(cursor: $cursor) {
+					nextCursor
+					entities {
+						id
+						name
+						type
+						active
+						properties {
+							key
+							value
+							label
+							displayValue
+						}
+						auth {
+							... on AiNotificationsBasicAuth {
+								authType
+								user
+							}
+							... on AiNotificationsTokenAuth {
+								authType
+								prefix
+							}
+						}
+						secureUrl {
+							prefix
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+	getChannels = `query($accountID: Int!, $cursor: String) {
+	actor {
+		account(id: $accountID) {
+			aiNotifications {
+				channels(cursor: $cursor) {
+					nextCursor
+					entities {
+						id
+						name
+						type
+						product
+						destinationId
+						active
+						properties {
+							key
+							value
+							label
+							displayValue
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+	getWorkflows = `query($accountID: Int!, $cursor: String) {
+	actor {
+		account(id: $accountID) {
+			aiWorkflows {
+				workflows(cursor: $cursor) {
+					nextCursor
+					entities {
+						id
+						name
+						workflowEnabled
+						destinationsEnabled
+						enrichmentsEnabled
+						mutingRulesHandling
+						issuesFilter {
+							name
+							type
+							predicates {
+								attribute
+								operator
+								values
+							}
+						}
+						enrichments {
+							name
+							type
+							configurations {
+								... on AiWorkflowsNrqlConfiguration {
+									query
+								}
+							}
+						}
+						destinationConfigurations {
+							channelId
+							notificationTriggers
+							updateOriginalMessage
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
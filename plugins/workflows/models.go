@@ -0,0 +1,117 @@
+package workflows
+
+type Destination struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Active     bool                   `json:"active"`
+	Properties []NotificationProperty `json:"properties"`
+	Auth       *DestinationAuth       `json:"auth"`
+	SecureURL  *DestinationSecureURL  `json:"secureUrl"`
+}
+
+// DestinationAuth is how a destination authenticates. NerdGraph never returns the password or token, only the
+// parts of the auth that aren't secret
+type DestinationAuth struct {
+	AuthType string `json:"authType"`
+	User     string `json:"user"`
+	Prefix   string `json:"prefix"`
+}
+
+type DestinationSecureURL struct {
+	Prefix string `json:"prefix"`
+}
+
+type NotificationProperty struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	Label        string `json:"label"`
+	DisplayValue string `json:"displayValue"`
+}
+
+type DestinationsResponse struct {
+	Actor struct {
+		Account struct {
+			AiNotifications struct {
+				Destinations struct {
+					NextCursor string        `json:"nextCursor"`
+					Entities   []Destination `json:"entities"`
+				} `json:"destinations"`
+			} `json:"aiNotifications"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+type Channel struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Product       string                 `json:"product"`
+	DestinationID string                 `json:"destinationId"`
+	Active        bool                   `json:"active"`
+	Properties    []NotificationProperty `json:"properties"`
+}
+
+type ChannelsResponse struct {
+	Actor struct {
+		Account struct {
+			AiNotifications struct {
+				Channels struct {
+					NextCursor string    `json:"nextCursor"`
+					Entities   []Channel `json:"entities"`
+				} `json:"channels"`
+			} `json:"aiNotifications"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+type Workflow struct {
+	ID                        string                      `json:"id"`
+	Name                      string                      `json:"name"`
+	WorkflowEnabled           bool                        `json:"workflowEnabled"`
+	DestinationsEnabled       bool                        `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                        `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                      `json:"mutingRulesHandling"`
+	IssuesFilter              *WorkflowIssuesFilter       `json:"issuesFilter"`
+	Enrichments               []WorkflowEnrichment        `json:"enrichments"`
+	DestinationConfigurations []WorkflowDestinationConfig `json:"destinationConfigurations"`
+}
+
+type WorkflowIssuesFilter struct {
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []WorkflowPredicate `json:"predicates"`
+}
+
+type WorkflowPredicate struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+type WorkflowEnrichment struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Configurations []struct {
+		Query string `json:"query"`
+	} `json:"configurations"`
+}
+
+type WorkflowDestinationConfig struct {
+	ChannelID             string   `json:"channelId"`
+	NotificationTriggers  []string `json:"notificationTriggers"`
+	UpdateOriginalMessage *bool    `json:"updateOriginalMessage"`
+}
+
+type WorkflowsResponse struct {
+	Actor struct {
+		Account struct {
+			AiWorkflows struct {
+				Workflows struct {
+					NextCursor string     `json:"nextCursor"`
+					Entities   []Workflow `json:"entities"`
+				} `json:"workflows"`
+			} `json:"aiWorkflows"`
+		} `json:"account"`
+	} `json:"actor"`
+}
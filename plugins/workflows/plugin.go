@@ -0,0 +1,362 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type WorkflowExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewWorkflowExporterCommand(options ...newrelic.ConfigOption) *WorkflowExporterCommand {
+	return &WorkflowExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (w *WorkflowExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(w)
+}
+
+func (w *WorkflowExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-workflows",
+		Description: "Export New Relic Workflows, and the Notification Channels and Destinations they notify, from the specified New Relic Account",
+		Summary:     "Export New Relic Workflows and Notification Channels and Destinations from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (w *WorkflowExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(w)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	w.nrClient, err = w.NewClient(w.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	w.outputDirectory = request.OutputDirectory
+
+	w.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	destinations, err := w.searchDestinations(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	channels, err := w.searchChannels(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	workflows, err := w.searchWorkflows(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := w.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	// every name is reserved before anything is rendered, so workflows can refer to channels, and channels to
+	// destinations, no matter which order they're exported in
+	for _, destination := range destinations {
+		w.resourceNames.Reserve(tfDestinationType, destination.ID, destination.Name, "destination")
+	}
+
+	for _, channel := range channels {
+		w.resourceNames.Reserve(tfChannelType, channel.ID, channel.Name, "channel")
+	}
+
+	for _, workflow := range workflows {
+		w.resourceNames.Reserve(tfWorkflowType, workflow.ID, workflow.Name, "workflow")
+	}
+
+	directives, destinationsError := internal.ExportInParallel(ctx, w.ParallelWorkers, destinations, w.exportDestination)
+	channelDirectives, channelsError := internal.ExportInParallel(ctx, w.ParallelWorkers, channels, w.exportChannel)
+	workflowDirectives, workflowsError := internal.ExportInParallel(ctx, w.ParallelWorkers, workflows, w.exportWorkflow)
+	directives = append(append(directives, channelDirectives...), workflowDirectives...)
+	commandError := errors.Join(destinationsError, channelsError, workflowsError)
+
+	w.resourceNames.Record(directives...)
+	if err := w.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return w.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+func (w *WorkflowExporterCommand) searchDestinations(ctx context.Context) ([]Destination, error) {
+	destinations := []Destination{}
+	queryVariables := map[string]any{"accountID": w.AccountID}
+	_, err := internal.QueryPages(ctx, &w.nrClient.NerdGraph, getDestinations, queryVariables, 0, func(response DestinationsResponse) (string, bool) {
+		page := response.Actor.Account.AiNotifications.Destinations
+		destinations = append(destinations, page.Entities...)
+		return page.NextCursor, true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for notification destinations: %w", err)
+	}
+
+	return destinations, nil
+}
+
+func (w *WorkflowExporterCommand) searchChannels(ctx context.Context) ([]Channel, error) {
+	channels := []Channel{}
+	queryVariables := map[string]any{"accountID": w.AccountID}
+	_, err := internal.QueryPages(ctx, &w.nrClient.NerdGraph, getChannels, queryVariables, 0, func(response ChannelsResponse) (string, bool) {
+		page := response.Actor.Account.AiNotifications.Channels
+		channels = append(channels, page.Entities...)
+		return page.NextCursor, true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for notification channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+func (w *WorkflowExporterCommand) searchWorkflows(ctx context.Context) ([]Workflow, error) {
+	workflows := []Workflow{}
+	queryVariables := map[string]any{"accountID": w.AccountID}
+	_, err := internal.QueryPages(ctx, &w.nrClient.NerdGraph, getWorkflows, queryVariables, 0, func(response WorkflowsResponse) (string, bool) {
+		page := response.Actor.Account.AiWorkflows.Workflows
+		workflows = append(workflows, page.Entities...)
+		return page.NextCursor, true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for workflows: %w", err)
+	}
+
+	return workflows, nil
+}
+
+// exportDestination writes a newrelic_notification_destination resource. New Relic never returns a
+// destination's password, token or secure URL suffix, so each of them is read from a sensitive variable that
+// has to be set before the configuration is applied
+func (w *WorkflowExporterCommand) exportDestination(_ context.Context, destination Destination) (plugin.ImportDirective, error) {
+	w.logger.Info("Exporting Notification Destination", "name", destination.Name)
+
+	tfResourceType := tfDestinationType
+	tfResourceName := w.resourceNames.Reserve(tfResourceType, destination.ID, destination.Name, "destination")
+
+	file := hclwrite.NewEmptyFile()
+	secret := func(suffix string, description string) hclwrite.Tokens {
+		variableName := fmt.Sprintf("destination_%s_%s", tfResourceName, suffix)
+		variable := file.Body().AppendNewBlock("variable", []string{variableName})
+		variable.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+		variable.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The %s of the %s notification destination", description, destination.Name)))
+		variable.Body().SetAttributeValue("sensitive", cty.BoolVal(true))
+		file.Body().AppendNewline()
+
+		return internal.CreateReference("var", variableName)
+	}
+
+	// the variables have to be declared before the resource that uses them is appended to the file
+	var password, token, secureSuffix hclwrite.Tokens
+	if destination.Auth != nil {
+		switch destination.Auth.AuthType {
+		case authTypeBasic:
+			password = secret("password", "password")
+		case authTypeToken:
+			token = secret("token", "token")
+		default:
+			w.logger.Info("WARN:", hclog.Fmt("notification destination %q uses %s auth, which can't be exported. Add its auth to the resource by hand", destination.Name, destination.Auth.AuthType))
+		}
+	}
+
+	if destination.SecureURL != nil {
+		secureSuffix = secret("secure_url_suffix", "secure URL suffix")
+	}
+
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(destination.Name))
+	body.SetAttributeValue("type", cty.StringVal(destination.Type))
+	body.SetAttributeValue("active", cty.BoolVal(destination.Active))
+	renderProperties(body, destination.Properties)
+
+	if password != nil {
+		body.AppendNewline()
+		auth := body.AppendNewBlock("auth_basic", nil)
+		auth.Body().SetAttributeValue("user", cty.StringVal(destination.Auth.User))
+		auth.Body().SetAttributeRaw("password", password)
+	}
+
+	if token != nil {
+		body.AppendNewline()
+		auth := body.AppendNewBlock("auth_token", nil)
+		if destination.Auth.Prefix != "" {
+			auth.Body().SetAttributeValue("prefix", cty.StringVal(destination.Auth.Prefix))
+		}
+		auth.Body().SetAttributeRaw("token", token)
+	}
+
+	if secureSuffix != nil {
+		body.AppendNewline()
+		secureURL := body.AppendNewBlock("secure_url", nil)
+		secureURL.Body().SetAttributeValue("prefix", cty.StringVal(destination.SecureURL.Prefix))
+		secureURL.Body().SetAttributeRaw("secure_suffix", secureSuffix)
+	}
+
+	directive, err := internal.WriteResourceFile(w.outputDirectory, file, destination.ID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering notification destination %q: %w", destination.Name, err)
+	}
+
+	return directive, nil
+}
+
+func (w *WorkflowExporterCommand) exportChannel(_ context.Context, channel Channel) (plugin.ImportDirective, error) {
+	w.logger.Info("Exporting Notification Channel", "name", channel.Name)
+
+	tfResourceType := tfChannelType
+	tfResourceName := w.resourceNames.Reserve(tfResourceType, channel.ID, channel.Name, "channel")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(channel.Name))
+	body.SetAttributeValue("type", cty.StringVal(channel.Type))
+	body.SetAttributeValue("product", cty.StringVal(channel.Product))
+	body.SetAttributeRaw("destination_id", w.reference(tfDestinationType, "notification destination", channel.DestinationID))
+	body.SetAttributeValue("active", cty.BoolVal(channel.Active))
+	renderProperties(body, channel.Properties)
+
+	directive, err := internal.WriteResourceFile(w.outputDirectory, file, channel.ID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering notification channel %q: %w", channel.Name, err)
+	}
+
+	return directive, nil
+}
+
+func (w *WorkflowExporterCommand) exportWorkflow(_ context.Context, workflow Workflow) (plugin.ImportDirective, error) {
+	w.logger.Info("Exporting Workflow", "name", workflow.Name)
+
+	tfResourceType := tfWorkflowType
+	tfResourceName := w.resourceNames.Reserve(tfResourceType, workflow.ID, workflow.Name, "workflow")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(workflow.Name))
+	body.SetAttributeValue("enabled", cty.BoolVal(workflow.WorkflowEnabled))
+	body.SetAttributeValue("destinations_enabled", cty.BoolVal(workflow.DestinationsEnabled))
+	body.SetAttributeValue("enrichments_enabled", cty.BoolVal(workflow.EnrichmentsEnabled))
+	body.SetAttributeValue("muting_rules_handling", cty.StringVal(workflow.MutingRulesHandling))
+
+	if workflow.IssuesFilter != nil {
+		body.AppendNewline()
+		filter := body.AppendNewBlock("issues_filter", nil)
+		filter.Body().SetAttributeValue("name", cty.StringVal(workflow.IssuesFilter.Name))
+		filter.Body().SetAttributeValue("type", cty.StringVal(workflow.IssuesFilter.Type))
+
+		for _, predicate := range workflow.IssuesFilter.Predicates {
+			filter.Body().AppendNewline()
+			predicateBlock := filter.Body().AppendNewBlock("predicate", nil)
+			predicateBlock.Body().SetAttributeValue("attribute", cty.StringVal(predicate.Attribute))
+			predicateBlock.Body().SetAttributeValue("operator", cty.StringVal(predicate.Operator))
+			predicateBlock.Body().SetAttributeValue("values", internal.ToCtyList(predicate.Values))
+		}
+	}
+
+	enrichments := []WorkflowEnrichment{}
+	for _, enrichment := range workflow.Enrichments {
+		if enrichment.Type != enrichmentTypeNrql {
+			w.logger.Info("WARN:", hclog.Fmt("enrichment %q of workflow %q is a %s enrichment, which terraform can't manage. Skipping", enrichment.Name, workflow.Name, enrichment.Type))
+			continue
+		}
+
+		enrichments = append(enrichments, enrichment)
+	}
+
+	if len(enrichments) > 0 {
+		body.AppendNewline()
+		enrichmentsBlock := body.AppendNewBlock("enrichments", nil)
+		for _, enrichment := range enrichments {
+			nrql := enrichmentsBlock.Body().AppendNewBlock("nrql", nil)
+			nrql.Body().SetAttributeValue("name", cty.StringVal(enrichment.Name))
+			for _, configuration := range enrichment.Configurations {
+				nrql.Body().AppendNewBlock("configuration", nil).Body().SetAttributeValue("query", cty.StringVal(configuration.Query))
+			}
+		}
+	}
+
+	for _, destination := range workflow.DestinationConfigurations {
+		body.AppendNewline()
+		destinationBlock := body.AppendNewBlock("destination", nil)
+		destinationBlock.Body().SetAttributeRaw("channel_id", w.reference(tfChannelType, "notification channel", destination.ChannelID))
+		destinationBlock.Body().SetAttributeValue("notification_triggers", internal.ToCtyList(destination.NotificationTriggers))
+		if destination.UpdateOriginalMessage != nil {
+			destinationBlock.Body().SetAttributeValue("update_original_message", cty.BoolVal(*destination.UpdateOriginalMessage))
+		}
+	}
+
+	directive, err := internal.WriteResourceFile(w.outputDirectory, file, workflow.ID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering workflow %q: %w", workflow.Name, err)
+	}
+
+	return directive, nil
+}
+
+// reference returns the address of the exported resource with the given ID. If it wasn't exported, the ID is
+// used as is
+func (w *WorkflowExporterCommand) reference(resourceType string, description string, id string) hclwrite.Tokens {
+	name, ok := w.resourceNames.Lookup(resourceType, id)
+	if !ok {
+		w.logger.Info("WARN:", hclog.Fmt("%s %s was not exported, so it is referred to by ID", description, id))
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+
+	return internal.CreateReference(resourceType, name, "id")
+}
+
+// renderProperties appends a property block for each of a destination's or channel's properties
+func renderProperties(body *hclwrite.Body, properties []NotificationProperty) {
+	for _, property := range properties {
+		body.AppendNewline()
+		propertyBlock := body.AppendNewBlock("property", nil)
+		propertyBlock.Body().SetAttributeValue("key", cty.StringVal(property.Key))
+		propertyBlock.Body().SetAttributeValue("value", cty.StringVal(property.Value))
+		if property.Label != "" {
+			propertyBlock.Body().SetAttributeValue("label", cty.StringVal(property.Label))
+		}
+		if property.DisplayValue != "" {
+			propertyBlock.Body().SetAttributeValue("display_value", cty.StringVal(property.DisplayValue))
+		}
+	}
+}
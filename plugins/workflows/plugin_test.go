@@ -0,0 +1,260 @@
+package workflows_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/workflows"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		w := &workflows.WorkflowExporterCommand{}
+		helpText, err := w.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+`))
+	})
+
+	It("Generates an info", func() {
+		w := &workflows.WorkflowExporterCommand{}
+		info, err := w.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-workflows"))
+		Expect(info.Version).To(Equal(plugin.FromString(workflows.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *workflows.WorkflowExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = workflows.NewWorkflowExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         []string{"-i", "56789", "-k", "1234"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_notification_destination", Name: "ops_webhook", ID: "d1a7b6c0-0000-4000-8000-000000000001"},
+				plugin.ImportDirective{Resource: "newrelic_notification_destination", Name: "on_call_email", ID: "d1a7b6c0-0000-4000-8000-000000000002"},
+				plugin.ImportDirective{Resource: "newrelic_notification_destination", Name: "chat_webhook", ID: "d1a7b6c0-0000-4000-8000-000000000003"},
+				plugin.ImportDirective{Resource: "newrelic_notification_channel", Name: "ops_webhook_payload", ID: "c4a11e10-0000-4000-8000-000000000001"},
+				plugin.ImportDirective{Resource: "newrelic_notification_channel", Name: "on_call_email_802209ba", ID: "c4a11e10-0000-4000-8000-000000000002"},
+				plugin.ImportDirective{Resource: "newrelic_workflow", Name: "checkout_issues", ID: "f10e0000-0000-4000-8000-000000000001"},
+			))
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Reads destination passwords from sensitive variables", func() {
+			Expect(readFile("ops_webhook.tf")).To(Equal(`variable "destination_ops_webhook_password" {
+  type        = string
+  description = "The password of the Ops Webhook notification destination"
+  sensitive   = true
+}
+
+resource "newrelic_notification_destination" "ops_webhook" {
+  name   = "Ops Webhook"
+  type   = "WEBHOOK"
+  active = true
+
+  property {
+    key   = "url"
+    value = "https://hooks.example.com/ops"
+  }
+
+  auth_basic {
+    user     = "ops"
+    password = var.destination_ops_webhook_password
+  }
+}
+`))
+		})
+
+		It("Reads destination tokens and secure URLs from sensitive variables", func() {
+			Expect(readFile("chat_webhook.tf")).To(Equal(`variable "destination_chat_webhook_token" {
+  type        = string
+  description = "The token of the Chat Webhook notification destination"
+  sensitive   = true
+}
+
+variable "destination_chat_webhook_secure_url_suffix" {
+  type        = string
+  description = "The secure URL suffix of the Chat Webhook notification destination"
+  sensitive   = true
+}
+
+resource "newrelic_notification_destination" "chat_webhook" {
+  name   = "Chat Webhook"
+  type   = "WEBHOOK"
+  active = false
+
+  auth_token {
+    prefix = "Bearer"
+    token  = var.destination_chat_webhook_token
+  }
+
+  secure_url {
+    prefix        = "https://chat.example.com/hooks/"
+    secure_suffix = var.destination_chat_webhook_secure_url_suffix
+  }
+}
+`))
+		})
+
+		It("Refers to destinations from channels", func() {
+			Expect(readFile("ops_webhook_payload.tf")).To(Equal(`resource "newrelic_notification_channel" "ops_webhook_payload" {
+  name           = "Ops Webhook Payload"
+  type           = "WEBHOOK"
+  product        = "IINT"
+  destination_id = newrelic_notification_destination.ops_webhook.id
+  active         = true
+
+  property {
+    key   = "payload"
+    value = "{\"issue\": \"{{ issueTitle }}\"}"
+    label = "Payload Template"
+  }
+}
+`))
+		})
+
+		It("Refers to channels from workflows", func() {
+			Expect(readFile("checkout_issues.tf")).To(Equal(`resource "newrelic_workflow" "checkout_issues" {
+  name                  = "Checkout Issues"
+  enabled               = true
+  destinations_enabled  = true
+  enrichments_enabled   = true
+  muting_rules_handling = "NOTIFY_ALL_ISSUES"
+
+  issues_filter {
+    name = "Checkout Issues Filter"
+    type = "FILTER"
+
+    predicate {
+      attribute = "labels.policyIds"
+      operator  = "EXACTLY_MATCHES"
+      values    = ["1001"]
+    }
+
+    predicate {
+      attribute = "priority"
+      operator  = "EQUAL"
+      values    = ["CRITICAL"]
+    }
+  }
+
+  enrichments {
+    nrql {
+      name = "Error Count"
+      configuration {
+        query = "SELECT count(*) FROM TransactionError"
+      }
+    }
+  }
+
+  destination {
+    channel_id            = newrelic_notification_channel.ops_webhook_payload.id
+    notification_triggers = ["ACTIVATED", "CLOSED"]
+  }
+
+  destination {
+    channel_id              = newrelic_notification_channel.on_call_email_802209ba.id
+    notification_triggers   = ["ACTIVATED"]
+    update_original_message = true
+  }
+}
+`))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query string `json:"query"`
+}
+
+func mockNerdGraphServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file := ""
+		switch {
+		case strings.Contains(request.Query, "destinations("):
+			file = "testdata/get_destinations.json"
+		case strings.Contains(request.Query, "channels("):
+			file = "testdata/get_channels.json"
+		case strings.Contains(request.Query, "workflows("):
+			file = "testdata/get_workflows.json"
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}
@@ -0,0 +1,13 @@
+package workflows_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkflows(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workflows Suite")
+}
@@ -0,0 +1,62 @@
+package workloads
+
+const (
+	tfWorkloadType = "newrelic_workload"
+
+	getWorkloads = `query($accountID: Int!) {
+	actor {
+		account(id: $accountID) {
+			workload {
+				collections {
+					id
+					guid
+					name
+					description
+					entities {
+						guid
+					}
+					entitySearchQueries {
+						query
+					}
+					scopeAccounts {
+						accountIds
+					}
+					statusConfig {
+						automatic {
+							enabled
+							remainingEntitiesRule {
+								rollup {
+									groupBy
+									strategy
+									thresholdType
+									thresholdValue
+								}
+							}
+							rules {
+								entities {
+									guid
+								}
+								entitySearchQueries {
+									query
+								}
+								rollup {
+									strategy
+									thresholdType
+									thresholdValue
+								}
+							}
+						}
+						static {
+							description
+							enabled
+							status
+							summary
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+)
@@ -0,0 +1,69 @@
+package workloads
+
+type Workload struct {
+	ID                  string                 `json:"id"`
+	GUID                string                 `json:"guid"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	Entities            []WorkloadEntity       `json:"entities"`
+	EntitySearchQueries []WorkloadEntitySearch `json:"entitySearchQueries"`
+	ScopeAccounts       *WorkloadScopeAccounts `json:"scopeAccounts"`
+	StatusConfig        *WorkloadStatusConfig  `json:"statusConfig"`
+}
+
+type WorkloadEntity struct {
+	GUID string `json:"guid"`
+}
+
+type WorkloadEntitySearch struct {
+	Query string `json:"query"`
+}
+
+type WorkloadScopeAccounts struct {
+	AccountIDs []int64 `json:"accountIds"`
+}
+
+type WorkloadStatusConfig struct {
+	Automatic *WorkloadAutomaticStatus `json:"automatic"`
+	Static    []WorkloadStaticStatus   `json:"static"`
+}
+
+// WorkloadAutomaticStatus is how a workload's status is rolled up from the statuses of its entities
+type WorkloadAutomaticStatus struct {
+	Enabled               bool `json:"enabled"`
+	RemainingEntitiesRule *struct {
+		Rollup WorkloadRollup `json:"rollup"`
+	} `json:"remainingEntitiesRule"`
+	Rules []WorkloadStatusRule `json:"rules"`
+}
+
+type WorkloadStatusRule struct {
+	Entities            []WorkloadEntity       `json:"entities"`
+	EntitySearchQueries []WorkloadEntitySearch `json:"entitySearchQueries"`
+	Rollup              WorkloadRollup         `json:"rollup"`
+}
+
+type WorkloadRollup struct {
+	GroupBy        string `json:"groupBy"`
+	Strategy       string `json:"strategy"`
+	ThresholdType  string `json:"thresholdType"`
+	ThresholdValue *int64 `json:"thresholdValue"`
+}
+
+// WorkloadStaticStatus is a status set by hand, which overrides the automatic status while it's enabled
+type WorkloadStaticStatus struct {
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Status      string `json:"status"`
+	Summary     string `json:"summary"`
+}
+
+type WorkloadsResponse struct {
+	Actor struct {
+		Account struct {
+			Workload struct {
+				Collections []Workload `json:"collections"`
+			} `json:"workload"`
+		} `json:"account"`
+	} `json:"actor"`
+}
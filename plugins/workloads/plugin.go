@@ -0,0 +1,229 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/internal"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var Version string = "0.0.1"
+
+type WorkloadExporterCommand struct {
+	internal.CommonFlags `embed:""`
+
+	NameLike        string `short:"n" help:"Only export workloads whose names contain this text."`
+	resourceNames   *internal.ResourceNames
+	nrClient        *newrelic.NewRelic
+	outputDirectory string
+	nrClientOptions []newrelic.ConfigOption
+	logger          hclog.Logger
+}
+
+func NewWorkloadExporterCommand(options ...newrelic.ConfigOption) *WorkloadExporterCommand {
+	return &WorkloadExporterCommand{
+		resourceNames:   internal.NewResourceNames(),
+		nrClientOptions: options,
+		logger:          internal.NewLogger(),
+	}
+}
+
+func (w *WorkloadExporterCommand) Help() (string, error) {
+	return internal.PluginCommandHelp(w)
+}
+
+func (w *WorkloadExporterCommand) Info() (plugin.CommandInfo, error) {
+	return plugin.CommandInfo{
+		Name:        "newrelic-workloads",
+		Description: "Export New Relic Workloads from the specified New Relic Account. Synthetic monitors exported to the same directory are referred to by address",
+		Summary:     "Export New Relic Workloads from the specified New Relic Account",
+		Version:     plugin.FromString(Version),
+	}, nil
+}
+
+func (w *WorkloadExporterCommand) Export(request plugin.ExportCommandRequest) (plugin.ExportResponse, error) {
+	var k *kong.Kong
+	k, err := kong.New(w)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	_, err = k.Parse(request.PluginArgs)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	ctx := context.Background()
+	w.nrClient, err = w.NewClient(w.nrClientOptions...)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+	w.outputDirectory = request.OutputDirectory
+
+	w.resourceNames, err = internal.LoadResourceNames(request.OutputDirectory)
+	if err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error loading resource names: %w", err)
+	}
+
+	workloads, err := w.listWorkloads(ctx)
+	if err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	if err := w.WriteAccountFiles(request); err != nil {
+		return plugin.ExportResponse{}, err
+	}
+
+	for _, workload := range workloads {
+		w.resourceNames.Reserve(tfWorkloadType, w.workloadImportID(workload), workload.Name, "workload")
+	}
+
+	directives, commandError := internal.ExportInParallel(ctx, w.ParallelWorkers, workloads, w.exportWorkload)
+
+	w.resourceNames.Record(directives...)
+	if err := w.resourceNames.Save(request.OutputDirectory); err != nil {
+		return plugin.ExportResponse{}, fmt.Errorf("error saving resource names: %w", err)
+	}
+
+	return w.ImportResponse(request.OutputDirectory, directives, commandError)
+}
+
+// listWorkloads lists the workloads in the account matching the name filter. NerdGraph returns every workload
+// in an account at once, so they're filtered here
+func (w *WorkloadExporterCommand) listWorkloads(ctx context.Context) ([]Workload, error) {
+	var response WorkloadsResponse
+	if err := w.nrClient.NerdGraph.QueryWithResponseAndContext(ctx, getWorkloads, map[string]any{"accountID": w.AccountID}, &response); err != nil {
+		return nil, fmt.Errorf("error querying NerdGraph for workloads: %w", err)
+	}
+
+	workloads := []Workload{}
+	for _, workload := range response.Actor.Account.Workload.Collections {
+		if strings.Contains(strings.ToLower(workload.Name), strings.ToLower(w.NameLike)) {
+			workloads = append(workloads, workload)
+		}
+	}
+
+	return workloads, nil
+}
+
+func (w *WorkloadExporterCommand) exportWorkload(_ context.Context, workload Workload) (plugin.ImportDirective, error) {
+	w.logger.Info("Exporting Workload", "name", workload.Name)
+
+	importID := w.workloadImportID(workload)
+	tfResourceType := tfWorkloadType
+	tfResourceName := w.resourceNames.Reserve(tfResourceType, importID, workload.Name, "workload")
+
+	file := hclwrite.NewEmptyFile()
+	block := file.Body().AppendNewBlock("resource", []string{tfResourceType, tfResourceName})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(workload.Name))
+	if workload.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(workload.Description))
+	}
+
+	if len(workload.Entities) > 0 {
+		body.SetAttributeRaw("entity_guids", w.entityReferences(workload.Entities))
+	}
+
+	if workload.ScopeAccounts != nil && len(workload.ScopeAccounts.AccountIDs) > 0 {
+		accountIDs := []cty.Value{}
+		for _, accountID := range workload.ScopeAccounts.AccountIDs {
+			accountIDs = append(accountIDs, cty.NumberIntVal(accountID))
+		}
+		body.SetAttributeValue("scope_account_ids", cty.ListVal(accountIDs))
+	}
+
+	for _, search := range workload.EntitySearchQueries {
+		body.AppendNewline()
+		body.AppendNewBlock("entity_search_query", nil).Body().SetAttributeValue("query", cty.StringVal(search.Query))
+	}
+
+	if workload.StatusConfig != nil {
+		w.renderStatusConfig(body, workload.StatusConfig)
+	}
+
+	directive, err := internal.WriteResourceFile(w.outputDirectory, file, importID, tfResourceType, tfResourceName)
+	if err != nil {
+		return plugin.ImportDirective{}, fmt.Errorf("error rendering workload %q: %w", workload.Name, err)
+	}
+
+	return directive, nil
+}
+
+func (w *WorkloadExporterCommand) renderStatusConfig(body *hclwrite.Body, statusConfig *WorkloadStatusConfig) {
+	if automatic := statusConfig.Automatic; automatic != nil {
+		body.AppendNewline()
+		automaticBlock := body.AppendNewBlock("status_config_automatic", nil)
+		automaticBlock.Body().SetAttributeValue("enabled", cty.BoolVal(automatic.Enabled))
+
+		if automatic.RemainingEntitiesRule != nil {
+			automaticBlock.Body().AppendNewline()
+			rule := automaticBlock.Body().AppendNewBlock("remaining_entities_rule", nil)
+			renderRollup(rule.Body(), "remaining_entities_rule_rollup", automatic.RemainingEntitiesRule.Rollup)
+		}
+
+		for _, rule := range automatic.Rules {
+			automaticBlock.Body().AppendNewline()
+			ruleBlock := automaticBlock.Body().AppendNewBlock("rule", nil)
+			if len(rule.Entities) > 0 {
+				ruleBlock.Body().SetAttributeRaw("entity_guids", w.entityReferences(rule.Entities))
+			}
+
+			for _, search := range rule.EntitySearchQueries {
+				ruleBlock.Body().AppendNewBlock("nrql_query", nil).Body().SetAttributeValue("query", cty.StringVal(search.Query))
+			}
+
+			renderRollup(ruleBlock.Body(), "rollup", rule.Rollup)
+		}
+	}
+
+	for _, static := range statusConfig.Static {
+		body.AppendNewline()
+		staticBlock := body.AppendNewBlock("status_config_static", nil)
+		if static.Description != "" {
+			staticBlock.Body().SetAttributeValue("description", cty.StringVal(static.Description))
+		}
+		staticBlock.Body().SetAttributeValue("enabled", cty.BoolVal(static.Enabled))
+		staticBlock.Body().SetAttributeValue("status", cty.StringVal(static.Status))
+		if static.Summary != "" {
+			staticBlock.Body().SetAttributeValue("summary", cty.StringVal(static.Summary))
+		}
+	}
+}
+
+// entityReferences returns a list of the entities' GUIDs, where the GUIDs of entities exported to the same
+// directory, such as synthetic monitors, are replaced with references to their resources
+func (w *WorkloadExporterCommand) entityReferences(entities []WorkloadEntity) hclwrite.Tokens {
+	guids := []hclwrite.Tokens{}
+	for _, entity := range entities {
+		guids = append(guids, internal.CreateEntityReference(w.resourceNames, entity.GUID))
+	}
+
+	return hclwrite.TokensForTuple(guids)
+}
+
+// workloadImportID returns the ID terraform imports a workload with, <account id>:<workload id>:<guid>
+func (w *WorkloadExporterCommand) workloadImportID(workload Workload) string {
+	return fmt.Sprintf("%d:%s:%s", w.AccountID, workload.ID, workload.GUID)
+}
+
+func renderRollup(body *hclwrite.Body, blockType string, rollup WorkloadRollup) {
+	rollupBlock := body.AppendNewBlock(blockType, nil)
+	if rollup.GroupBy != "" {
+		rollupBlock.Body().SetAttributeValue("group_by", cty.StringVal(rollup.GroupBy))
+	}
+	rollupBlock.Body().SetAttributeValue("strategy", cty.StringVal(rollup.Strategy))
+	if rollup.ThresholdType != "" {
+		rollupBlock.Body().SetAttributeValue("threshold_type", cty.StringVal(rollup.ThresholdType))
+	}
+	if rollup.ThresholdValue != nil {
+		rollupBlock.Body().SetAttributeValue("threshold_value", cty.NumberIntVal(*rollup.ThresholdValue))
+	}
+}
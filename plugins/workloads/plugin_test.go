@@ -0,0 +1,221 @@
+package workloads_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gideaworx/terraform-exporter-newrelic-plugin/plugins/workloads"
+	plugin "github.com/gideaworx/terraform-exporter-plugin-go"
+)
+
+var _ = Describe("Plugin", func() {
+	It("Generates help", func() {
+		w := &workloads.WorkloadExporterCommand{}
+		helpText, err := w.Help()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(helpText).To(Equal(`
+Flags:
+  -i, --account-id=INT             The New Relic Account ID
+  -k, --api-key=STRING             An API Key for the New Relic Acccount ID
+  -r, --region="US"                The region the New Relic Account is in.
+                                   One of US or EU.
+      --import-mode="directives"
+                                   How exported resources are imported. One
+                                   of directives (terraform import commands),
+                                   blocks (import blocks in imports.tf),
+                                   or both.
+      --provider-version=STRING    A version constraint for the New Relic
+                                   provider, such as "~> 3.0". By default,
+                                   the provider version isn't constrained.
+      --sensitive-api-key          Mark the api_key variable as sensitive.
+  -n, --name-like=STRING           Only export workloads whose names contain
+                                   this text.
+`))
+	})
+
+	It("Generates an info", func() {
+		w := &workloads.WorkloadExporterCommand{}
+		info, err := w.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Name).To(Equal("newrelic-workloads"))
+		Expect(info.Version).To(Equal(plugin.FromString(workloads.Version)))
+	})
+
+	Describe("Export", func() {
+		var (
+			server          *httptest.Server
+			command         *workloads.WorkloadExporterCommand
+			outputDirectory string
+		)
+
+		BeforeEach(func() {
+			server = mockNerdGraphServer()
+
+			var err error
+			outputDirectory, err = os.MkdirTemp("", "nrtftmp")
+			Expect(err).NotTo(HaveOccurred())
+
+			command = workloads.NewWorkloadExporterCommand(
+				newrelic.ConfigBaseURL(server.URL),
+				newrelic.ConfigNerdGraphBaseURL(server.URL),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			Expect(os.RemoveAll(outputDirectory)).To(Succeed())
+		})
+
+		export := func(args ...string) plugin.ExportResponse {
+			resp, err := command.Export(plugin.ExportCommandRequest{
+				OutputDirectory:    outputDirectory,
+				SkipProviderOutput: true,
+				PluginArgs:         append([]string{"-i", "56789", "-k", "1234"}, args...),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return resp
+		}
+
+		readFile := func(name string) string {
+			b, err := os.ReadFile(filepath.Join(outputDirectory, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		It("Exports every workload in the account", func() {
+			resp := export()
+			Expect(resp.Directives).To(ConsistOf(
+				plugin.ImportDirective{Resource: "newrelic_workload", Name: "checkout_journey", ID: "56789:301:NTY3ODl8TkIxfFdPUktMT0FEfDMwMQ"},
+				plugin.ImportDirective{Resource: "newrelic_workload", Name: "inventory", ID: "56789:302:NTY3ODl8TkIxfFdPUktMT0FEfDMwMg"},
+			))
+
+			Expect(readFile("inventory.tf")).To(Equal(`resource "newrelic_workload" "inventory" {
+  name              = "Inventory"
+  scope_account_ids = [56789]
+
+  entity_search_query {
+    query = "tags.team = 'inventory'"
+  }
+}
+`))
+		})
+
+		It("Filters workloads by name", func() {
+			resp := export("-n", "CHECKOUT")
+			Expect(resp.Directives).To(HaveLen(1))
+			Expect(resp.Directives[0].Name).To(Equal("checkout_journey"))
+		})
+
+		It("Refers to synthetic monitors exported to the same directory", func() {
+			names := `{
+  "names": {
+    "newrelic_synthetics_monitor": {
+      "NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDE": "checkout_ping"
+    },
+    "newrelic_synthetics_script_monitor": {
+      "NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDI": "checkout_flow"
+    }
+  },
+  "exported": {
+    "newrelic_synthetics_monitor": [
+      "NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDE"
+    ],
+    "newrelic_synthetics_script_monitor": [
+      "NTY3ODl8U1lOVEh8TU9OSVRPUnwzZjFhMmI0Yy0wMDAwLTQwMDAtODAwMC0wMDAwMDAwMDAwMDI"
+    ]
+  }
+}`
+			Expect(os.WriteFile(filepath.Join(outputDirectory, ".resource_names.json"), []byte(names), 0644)).To(Succeed())
+
+			export()
+			Expect(readFile("checkout_journey.tf")).To(Equal(`resource "newrelic_workload" "checkout_journey" {
+  name              = "Checkout Journey"
+  description       = "Everything the checkout depends on"
+  entity_guids      = [newrelic_synthetics_monitor.checkout_ping.id, "NTY3ODl8QVBNfEFQUExJQ0FUSU9OfDQwMDE"]
+  scope_account_ids = [56789, 98765]
+
+  entity_search_query {
+    query = "name LIKE 'checkout%' AND type = 'MONITOR'"
+  }
+
+  status_config_automatic {
+    enabled = true
+
+    remaining_entities_rule {
+      remaining_entities_rule_rollup {
+        group_by = "ENTITY_TYPE"
+        strategy = "WORST_STATUS_WINS"
+      }
+    }
+
+    rule {
+      entity_guids = [newrelic_synthetics_script_monitor.checkout_flow.id]
+      nrql_query {
+        query = "type = 'APPLICATION'"
+      }
+      rollup {
+        strategy        = "BEST_STATUS_WINS"
+        threshold_type  = "FIXED"
+        threshold_value = 1
+      }
+    }
+  }
+
+  status_config_static {
+    description = "Planned maintenance"
+    enabled     = false
+    status      = "DEGRADED"
+    summary     = "Maintenance window"
+  }
+}
+`))
+		})
+	})
+})
+
+type nerdgraphQuery struct {
+	Query string `json:"query"`
+}
+
+func mockNerdGraphServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			return
+		}
+
+		defer r.Body.Close()
+
+		b, _ := io.ReadAll(r.Body)
+		var request nerdgraphQuery
+		if err := json.Unmarshal(b, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !strings.Contains(request.Query, "collections") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		data, err := os.ReadFile("testdata/get_workloads.json")
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}))
+}